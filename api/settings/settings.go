@@ -237,6 +237,11 @@ type Settings struct {
 	// By default, this is disabled.
 	XdsTLS bool `split_words:"true" default:"false"`
 
+	// XdsTLSCaBestEffort treats a missing xDS TLS CA certificate file as transient when XdsTLS is enabled:
+	// affected Gateways are requeued until the file is mounted instead of being marked as invalid.
+	// An empty CA certificate file is always an error. By default, this is disabled.
+	XdsTLSCaBestEffort bool `split_words:"true" default:"false"`
+
 	// DefaultImageRegistry is the default image registry to use for the kgateway image.
 	DefaultImageRegistry string `split_words:"true" default:"cr.kgateway.dev"`
 	// DefaultImageTag is the default image tag to use for the kgateway image.
@@ -340,6 +345,23 @@ type Settings struct {
 	// E.g., {"gateway-class-name":{"name":"params-name","namespace":"params-namespace","group":"gateway.networking.k8s.io","kind":"GatewayParameters"}}
 	GatewayClassParametersRefs GatewayClassParametersRefs `split_words:"true" default:"{}"`
 
+	// RequireGatewayParameters rejects Gateways that resolve to no GatewayParameters, either through
+	// their own infrastructure.parametersRef or through their GatewayClass parametersRef.
+	// When enabled, such Gateways are marked Accepted=False instead of being deployed with the built-in defaults.
+	RequireGatewayParameters bool `split_words:"true" default:"false"`
+
+	// ListenerProtocolPodLabelPrefix, when set, labels the proxy pods of each managed Gateway with
+	// <prefix>/<protocol>=true for every listener protocol, e.g. example.com/https=true, so that
+	// the pods can be selected by the protocols they serve. The prefix must be a DNS subdomain.
+	// Labels used by the proxy Deployment's selector are never overwritten.
+	ListenerProtocolPodLabelPrefix string `split_words:"true"`
+
+	// EnableGatewayEffectiveConfig sets an EffectiveConfig condition on each managed Gateway whose
+	// message summarizes the resolved proxy image, log level and resources, so that they can be
+	// read from the Gateway without resolving its GatewayParameters. Disabling it removes the
+	// condition from the Gateways on their next reconcile.
+	EnableGatewayEffectiveConfig bool `split_words:"true" default:"false"`
+
 	// Enables setting the `dev.kgateway.auth_policy:auth_succeeded=true` dynamic metadata on successfully-authenticated routes.
 	EnableAuthMetadata bool `split_words:"true" default:"false"`
 
@@ -49,9 +49,13 @@ func allEnvVarsSet() map[string]string {
 		"KGW_AWS_EC2_REFRESH_INTERVAL":                  "45s",
 		"KGW_POLICY_MERGE":                              `{"TrafficPolicy":{"extProc":"DeepMerge"}}`,
 		"KGW_GATEWAY_CLASS_PARAMETERS_REFS":             `{"kgateway":{"name":"custom-gwp","namespace":"infra"}}`,
+		"KGW_REQUIRE_GATEWAY_PARAMETERS":                "true",
+		"KGW_LISTENER_PROTOCOL_POD_LABEL_PREFIX":        "protocol.example.com",
+		"KGW_ENABLE_GATEWAY_EFFECTIVE_CONFIG":           "true",
 		"KGW_ENABLE_WAYPOINT":                           "true",
 		"KGW_XDS_AUTH":                                  "false",
 		"KGW_XDS_TLS":                                   "true",
+		"KGW_XDS_TLS_CA_BEST_EFFORT":                    "true",
 		"KGW_ENABLE_EXPERIMENTAL_GATEWAY_API_FEATURES":  "false",
 		"KGW_ENABLE_AUTH_METADATA":                      "true",
 		"KGW_WORKLOAD_ENTRIES_EXCLUSION_LABELS":         "example.io/managed-by,example.io/other-key",
@@ -113,8 +117,11 @@ func TestSettings(t *testing.T) {
 				EnableWaypoint:                        false,
 				XdsAuth:                               true,
 				XdsTLS:                                false,
+				XdsTLSCaBestEffort:                    false,
 				EnableExperimentalGatewayAPIFeatures:  true,
 				GatewayClassParametersRefs:            GatewayClassParametersRefs{},
+				RequireGatewayParameters:              false,
+				EnableGatewayEffectiveConfig:          false,
 				EnableAuthMetadata:                    false,
 				ServiceEntriesExclusionLabelSelectors: "[]",
 			},
@@ -156,6 +163,7 @@ func TestSettings(t *testing.T) {
 				EnableWaypoint:                        true,
 				XdsAuth:                               false,
 				XdsTLS:                                true,
+				XdsTLSCaBestEffort:                    true,
 				EnableExperimentalGatewayAPIFeatures:  false,
 				WorkloadEntriesExclusionLabels:        "example.io/managed-by,example.io/other-key",
 				ServiceEntriesExclusionLabelSelectors: `[{"matchLabels":{"example.io/managed-by":"some-controller"}}]`,
@@ -165,9 +173,12 @@ func TestSettings(t *testing.T) {
 						Namespace: new(gwv1.Namespace("infra")),
 					},
 				},
-				EnableAuthMetadata:        true,
-				EnableRouteSourceMetadata: true,
-				ReferenceGrantMode:        ReferenceGrantStrict,
+				RequireGatewayParameters:       true,
+				ListenerProtocolPodLabelPrefix: "protocol.example.com",
+				EnableGatewayEffectiveConfig:   true,
+				EnableAuthMetadata:             true,
+				EnableRouteSourceMetadata:      true,
+				ReferenceGrantMode:             ReferenceGrantStrict,
 			},
 		},
 		{
@@ -266,6 +277,7 @@ func TestSettings(t *testing.T) {
 				PolicyMerge:                           "{}",
 				XdsAuth:                               true,
 				XdsTLS:                                false,
+				XdsTLSCaBestEffort:                    false,
 				EnableExperimentalGatewayAPIFeatures:  true,
 				GatewayClassParametersRefs:            GatewayClassParametersRefs{},
 				ServiceEntriesExclusionLabelSelectors: "[]",
@@ -812,6 +812,11 @@ type GatewayParametersOverlays struct {
 	// If absent, no PDB is created. If present, a PDB is created with its selector
 	// automatically configured to target the proxy Deployment.
 	// The metadata and spec fields from this overlay are applied to the generated PDB.
+	// When both the GatewayClass and the Gateway parameters set this field, a single PDB is
+	// created from the GatewayClass overlay and the Gateway overlay is merged on top of it
+	// using strategic merge patch semantics, so fields the Gateway overlay does not set keep
+	// their GatewayClass values. If the Gateway overlay sets only one of minAvailable and
+	// maxUnavailable, the other one is cleared, since a PDB may set at most one of them.
 	// +optional
 	PodDisruptionBudget *shared.KubernetesResourceOverlay `json:"podDisruptionBudget,omitempty"`
 
@@ -819,6 +824,10 @@ type GatewayParametersOverlays struct {
 	// If absent, no HPA is created. If present, an HPA is created with its scaleTargetRef
 	// automatically configured to target the proxy Deployment.
 	// The metadata and spec fields from this overlay are applied to the generated HPA.
+	// When both the GatewayClass and the Gateway parameters set this field, a single HPA is
+	// created from the GatewayClass overlay and the Gateway overlay is merged on top of it
+	// using strategic merge patch semantics, so fields the Gateway overlay does not set keep
+	// their GatewayClass values.
 	// +optional
 	HorizontalPodAutoscaler *shared.KubernetesResourceOverlay `json:"horizontalPodAutoscaler,omitempty"`
 
@@ -826,6 +835,10 @@ type GatewayParametersOverlays struct {
 	// If absent, no VPA is created. If present, a VPA is created with its targetRef
 	// automatically configured to target the proxy Deployment.
 	// The metadata and spec fields from this overlay are applied to the generated VPA.
+	// When both the GatewayClass and the Gateway parameters set this field, a single VPA is
+	// created from the GatewayClass overlay and the Gateway overlay is merged on top of it.
+	// The spec of each overlay is applied as a JSON merge patch (RFC 7386): nested objects
+	// are merged, lists are replaced and a null value removes the field.
 	// +optional
 	VerticalPodAutoscaler *shared.KubernetesResourceOverlay `json:"verticalPodAutoscaler,omitempty"`
 }
@@ -90,6 +90,11 @@ func fakeIstioClient(objects ...client.Object) kube.Client {
 		if err != nil {
 			panic(fmt.Sprintf("failed to convert to unstructured for object %T %s: %v", obj, nn, err))
 		}
+		// Typed objects are often built without TypeMeta; set it so dynamic
+		// readers (e.g. for BackendTLSPolicy) see the correct apiVersion/kind.
+		if us.GroupVersionKind().Empty() {
+			us.SetGroupVersionKind(getGVK(obj, kube.IstioScheme))
+		}
 		_, err = d.Create(context.Background(), us, metav1.CreateOptions{})
 		if err != nil {
 			panic(fmt.Sprintf("failed to create in dynamic client for object %T %s: %v", obj, nn, err))
@@ -142,12 +147,22 @@ func mustGetGVR(obj client.Object, scheme *runtime.Scheme) schema.GroupVersionRe
 	return gvr
 }
 
-func getGVR(obj client.Object, scheme *runtime.Scheme) (schema.GroupVersionResource, error) {
+func getGVK(obj client.Object, scheme *runtime.Scheme) schema.GroupVersionKind {
 	gvk := obj.GetObjectKind().GroupVersionKind()
 	if gvk.Group == "" {
 		gvks, _, _ := scheme.ObjectKinds(obj)
 		gvk = gvks[0]
 	}
+	return gvk
+}
+
+func getGVR(obj client.Object, scheme *runtime.Scheme) (schema.GroupVersionResource, error) {
+	gvk := getGVK(obj, scheme)
+	if gvk == wellknown.BackendTLSPolicyGVK {
+		// Resolve explicitly rather than relying on the Istio GVK table matching
+		// the Gateway API version vendored here.
+		return wellknown.BackendTLSPolicyGVR, nil
+	}
 	gvr, err := wellknown.GVKToGVR(gvk)
 	if err != nil {
 		// try unsafe guess
@@ -5,7 +5,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"istio.io/istio/pkg/config/schema/gvr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 )
@@ -28,3 +31,37 @@ func TestKindForSeededCRD(t *testing.T) {
 		})
 	}
 }
+
+func TestBackendTLSPolicyRoundTrip(t *testing.T) {
+	policy := &gwv1.BackendTLSPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tls-policy",
+			Namespace: "default",
+		},
+		Spec: gwv1.BackendTLSPolicySpec{
+			TargetRefs: []gwv1.LocalPolicyTargetReferenceWithSectionName{{
+				LocalPolicyTargetReference: gwv1.LocalPolicyTargetReference{
+					Kind: "Service",
+					Name: "backend",
+				},
+			}},
+			Validation: gwv1.BackendTLSPolicyValidation{
+				Hostname:                "backend.example.com",
+				WellKnownCACertificates: new(gwv1.WellKnownCACertificatesSystem),
+			},
+		},
+	}
+
+	c := NewClient(t, policy)
+
+	got, err := c.Dynamic().Resource(wellknown.BackendTLSPolicyGVR).Namespace(policy.Namespace).Get(t.Context(), policy.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, wellknown.BackendTLSPolicyKind, got.GetKind())
+	hostname, _, err := unstructured.NestedString(got.Object, "spec", "validation", "hostname")
+	require.NoError(t, err)
+	require.Equal(t, "backend.example.com", hostname)
+
+	typed, err := c.GatewayAPI().GatewayV1().BackendTLSPolicies(policy.Namespace).Get(t.Context(), policy.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, policy.Spec.Validation.Hostname, typed.Spec.Validation.Hostname)
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
@@ -14,6 +16,8 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/storage"
 	"helm.sh/helm/v3/pkg/storage/driver"
+	"istio.io/istio/pkg/config/schema/collections"
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,8 +25,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	k8syamlutil "sigs.k8s.io/yaml"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
 	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
@@ -32,11 +40,20 @@ import (
 
 var logger = logging.New("deployer")
 
+// ErrMissingRequiredObjects is returned when the objects to deploy do not include an object
+// of every kind registered with WithRequiredKinds.
+var ErrMissingRequiredObjects = errors.New("missing required objects")
+
+// ErrNoObjectsRendered is returned when the chart, after post-processing, renders no objects
+// at all, e.g. because of a broken custom chart or values, regardless of WithRequiredKinds.
+var ErrNoObjectsRendered = errors.New("no objects rendered")
+
 type ControlPlaneInfo struct {
-	XdsHost      string
-	XdsPort      uint32
-	XdsTLS       bool
-	XdsTlsCaPath string
+	XdsHost            string
+	XdsPort            uint32
+	XdsTLS             bool
+	XdsTlsCaPath       string
+	XdsTlsCaBestEffort bool
 }
 
 type ImageInfo struct {
@@ -59,6 +76,9 @@ type Deployer struct {
 	helmReleaseNameAndNamespaceGenerator func(obj client.Object) (string, string)
 	gvkToGVRMapper                       map[schema.GroupVersionKind]schema.GroupVersionResource
 	patcher                              Patcher
+	objectPostProcessors                 []ObjectPostProcessor
+	objectContributors                   []ObjectContributor
+	requiredKinds                        []schema.GroupVersionKind
 }
 
 type Option func(*Deployer)
@@ -81,6 +101,81 @@ func WithManagedBy(managedBy string) Option {
 	}
 }
 
+// WithObjectPostProcessors adds post-processors that run on the rendered objects, in the order
+// given, after the HelmValuesGenerator's own post-processing (e.g. GatewayParameters overlays).
+// An error from any post-processor aborts deployment of the object.
+func WithObjectPostProcessors(postProcessors ...ObjectPostProcessor) Option {
+	return func(d *Deployer) {
+		d.objectPostProcessors = append(d.objectPostProcessors, postProcessors...)
+	}
+}
+
+// PodTemplateLabelsFunc derives labels for the pod template of the rendered Deployment from
+// the object being deployed (e.g. a label per listener protocol of a Gateway).
+type PodTemplateLabelsFunc func(obj client.Object) map[string]string
+
+// WithPodTemplateLabels stamps the labels returned by fn onto the pod template of every rendered
+// Deployment. It runs as a post-processor, in the order it is given relative to
+// WithObjectPostProcessors. Labels used by the Deployment's selector are never overwritten, as
+// that would orphan the existing pods.
+func WithPodTemplateLabels(fn PodTemplateLabelsFunc) Option {
+	return WithObjectPostProcessors(podTemplateLabeler(fn))
+}
+
+type podTemplateLabeler PodTemplateLabelsFunc
+
+func (fn podTemplateLabeler) PostProcessObjects(_ context.Context, obj client.Object, rendered []client.Object) ([]client.Object, error) {
+	labels := fn(obj)
+	if len(labels) == 0 {
+		return rendered, nil
+	}
+	for _, renderedObj := range rendered {
+		dep, ok := renderedObj.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+		if dep.Spec.Template.Labels == nil {
+			dep.Spec.Template.Labels = make(map[string]string, len(labels))
+		}
+		for k, v := range labels {
+			if dep.Spec.Selector != nil {
+				if _, ok := dep.Spec.Selector.MatchLabels[k]; ok {
+					continue
+				}
+			}
+			dep.Spec.Template.Labels[k] = v
+		}
+	}
+	return rendered, nil
+}
+
+// WithObjectContributors adds contributors whose objects are deployed alongside the rendered chart.
+// Contributors are called in the order given. ValidateContributedKinds reports a contributor that
+// declares a kind that cannot be mapped to a resource, see WithGVKToGVRMapper.
+func WithObjectContributors(contributors ...ObjectContributor) Option {
+	return func(d *Deployer) {
+		d.objectContributors = append(d.objectContributors, contributors...)
+	}
+}
+
+// WithRequiredKinds requires the objects to deploy to include at least one object of each of
+// the given kinds. GetObjsToDeploy returns ErrMissingRequiredObjects otherwise, so that a
+// misconfiguration that drops e.g. the Deployment is reported instead of silently applying
+// an incomplete set of objects.
+func WithRequiredKinds(kinds ...schema.GroupVersionKind) Option {
+	return func(d *Deployer) {
+		d.requiredKinds = append(d.requiredKinds, kinds...)
+	}
+}
+
+// WithChart renders the given chart instead of the one the deployer was created with, e.g. to
+// try out changes to the chart templates without rebuilding the embedded chart.
+func WithChart(c *chart.Chart) Option {
+	return func(d *Deployer) {
+		d.chart = c
+	}
+}
+
 // NewDeployer creates a new deployer for managed resources.
 func NewDeployer(
 	controllerName string,
@@ -107,6 +202,61 @@ func NewDeployer(
 	return d
 }
 
+// ValidateContributedKinds returns an error if a kind declared by an object contributor cannot
+// be mapped to a resource, so that such objects could be neither deployed nor pruned, or if the
+// kind is cluster-scoped, as contributed objects are owned by and pruned within the namespace of
+// a Gateway. Callers should check it once after NewDeployer, e.g. at startup.
+func (d *Deployer) ValidateContributedKinds() error {
+	for _, gvk := range d.contributedKinds() {
+		gvr, err := d.gvkToGVR(gvk)
+		if err != nil {
+			return fmt.Errorf("unsupported contributed kind: %w", err)
+		}
+		if d.isClusterScoped(gvk, gvr) {
+			return fmt.Errorf("unsupported contributed kind: %v is cluster-scoped", gvk)
+		}
+	}
+	return nil
+}
+
+// isClusterScoped reports whether the given kind is cluster-scoped. Kinds that are not known
+// statically are looked up through the API server's discovery information; a kind discovery
+// cannot resolve, e.g. because its CRD is not installed yet, is assumed to be namespaced.
+func (d *Deployer) isClusterScoped(gvk schema.GroupVersionKind, gvr schema.GroupVersionResource) bool {
+	if !kubeutils.IsNamespacedGVK(gvk) {
+		return true
+	}
+	if s, found := collections.All.FindByGroupVersionResource(gvr); found {
+		return s.IsClusterScoped()
+	}
+	if d.client == nil {
+		return false
+	}
+	resources, err := d.client.Kube().Discovery().ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if err != nil {
+		return false
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == gvr.Resource {
+			return !r.Namespaced
+		}
+	}
+	return false
+}
+
+// contributedKinds returns the kinds declared by the object contributors, without duplicates.
+func (d *Deployer) contributedKinds() []schema.GroupVersionKind {
+	var kinds []schema.GroupVersionKind
+	for _, contributor := range d.objectContributors {
+		for _, gvk := range contributor.ContributedKinds() {
+			if !slices.Contains(kinds, gvk) {
+				kinds = append(kinds, gvk)
+			}
+		}
+	}
+	return kinds
+}
+
 func applyPatch(ctx context.Context, client apiclient.Client, fieldManager string, gvr schema.GroupVersionResource, name string, namespace string, data []byte, subresources ...string) error {
 	c := client.Dynamic().Resource(gvr).Namespace(namespace)
 	_, err := c.Patch(ctx, name, types.ApplyPatchType, data, metav1.PatchOptions{
@@ -209,8 +359,13 @@ func (d *Deployer) GetObjsToDeploy(ctx context.Context, obj client.Object) ([]cl
 		return nil, fmt.Errorf("failed to get objects to deploy %s.%s: %w", obj.GetNamespace(), obj.GetName(), err)
 	}
 
-	// Apply post-processing if the HelmValuesGenerator implements ObjectPostProcessor
+	// Apply post-processing if the HelmValuesGenerator implements ObjectPostProcessor,
+	// followed by any additional post-processors in order
+	postProcessors := d.objectPostProcessors
 	if postProcessor, ok := d.helmValues.(ObjectPostProcessor); ok {
+		postProcessors = append([]ObjectPostProcessor{postProcessor}, postProcessors...)
+	}
+	for _, postProcessor := range postProcessors {
 		var err error
 		objs, err = postProcessor.PostProcessObjects(ctx, obj, objs)
 		if err != nil {
@@ -218,9 +373,116 @@ func (d *Deployer) GetObjsToDeploy(ctx context.Context, obj client.Object) ([]cl
 		}
 	}
 
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("%w for %s.%s", ErrNoObjectsRendered, obj.GetNamespace(), obj.GetName())
+	}
+
+	for _, contributor := range d.objectContributors {
+		contributed, err := contributor.ContributeObjects(ctx, obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contributed objects for %s.%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+		kinds := contributor.ContributedKinds()
+		for _, c := range contributed {
+			gvk, err := apiutil.GVKForObject(c, d.scheme)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get kind of contributed object %s for %s.%s: %w", c.GetName(), obj.GetNamespace(), obj.GetName(), err)
+			}
+			if !slices.Contains(kinds, gvk) {
+				return nil, fmt.Errorf("contributed object %s %s for %s.%s is not of a declared contributed kind",
+					gvk.String(), c.GetName(), obj.GetNamespace(), obj.GetName())
+			}
+			// typed objects are usually built without TypeMeta; deploying needs the GVK
+			c.GetObjectKind().SetGroupVersionKind(gvk)
+			// label contributed objects with their Gateway so that PruneRemovedResources finds them
+			labels := c.GetLabels()
+			if labels == nil {
+				labels = make(map[string]string, 1)
+			}
+			labels[wellknown.GatewayNameLabel] = kubeutils.SafeGatewayLabelValue(obj.GetName())
+			c.SetLabels(labels)
+		}
+		objs = append(objs, contributed...)
+	}
+
+	if err := d.checkRequiredKinds(objs); err != nil {
+		return nil, fmt.Errorf("%w for %s.%s: %w", ErrMissingRequiredObjects, obj.GetNamespace(), obj.GetName(), err)
+	}
+
 	return objs, nil
 }
 
+// RenderToDir writes the objects that would be deployed for obj to dir, one YAML file per object
+// named <kind>-<name>.yaml, e.g. to export them for GitOps. Namespaced objects get the namespace
+// of obj; owner references, the server-populated metadata fields (uid, resourceVersion,
+// generation, managedFields and creationTimestamp) and status are omitted. dir is created if
+// it does not exist.
+func (d *Deployer) RenderToDir(ctx context.Context, obj client.Object, dir string) error {
+	objs, err := d.GetObjsToDeploy(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	written := sets.New[string]()
+	for _, renderedObj := range objs {
+		u, err := kubeutils.ToUnstructured(renderedObj)
+		if err != nil {
+			return fmt.Errorf("error converting object %s to unstructured: %w", kubeutils.NamespacedNameFrom(renderedObj), err)
+		}
+		u = u.DeepCopy()
+		if kubeutils.IsNamespacedGVK(u.GroupVersionKind()) && u.GetNamespace() == "" {
+			u.SetNamespace(obj.GetNamespace())
+		}
+		for _, field := range []string{"ownerReferences", "uid", "resourceVersion", "generation", "managedFields", "creationTimestamp"} {
+			unstructured.RemoveNestedField(u.Object, "metadata", field)
+		}
+		unstructured.RemoveNestedField(u.Object, "status")
+
+		fileName := strings.ToLower(u.GetKind()) + "-" + u.GetName() + ".yaml"
+		if written.Has(fileName) {
+			return fmt.Errorf("multiple objects render to %s", fileName)
+		}
+		written.Insert(fileName)
+
+		data, err := k8syamlutil.Marshal(u.Object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s %s: %w", u.GetKind(), u.GetName(), err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, fileName), data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", fileName, err)
+		}
+	}
+	return nil
+}
+
+// checkRequiredKinds returns an error naming the required kinds that have no object in objs.
+func (d *Deployer) checkRequiredKinds(objs []client.Object) error {
+	if len(d.requiredKinds) == 0 {
+		return nil
+	}
+	present := make(map[schema.GroupVersionKind]bool, len(objs))
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, d.scheme)
+		if err != nil {
+			continue
+		}
+		present[gvk] = true
+	}
+	var missing []string
+	for _, gvk := range d.requiredKinds {
+		if !present[gvk] {
+			missing = append(missing, gvk.Kind)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("rendered objects do not include a %s", strings.Join(missing, " and a "))
+	}
+	return nil
+}
+
 // Deprecated: use SetNamespaceAndOwnerWithGVK
 // Using this without specifying the GVK breaks with client-go clients which do not set the
 // GVK in the TypeMeta after the initial List()
@@ -283,8 +545,6 @@ func (d *Deployer) DeployObjsWithSource(ctx context.Context, objs []client.Objec
 		// Get the existing object from the cache to check if it needs to be updated
 		c := d.client.Dynamic().Resource(gvr).Namespace(obj.GetNamespace())
 		existing, err := c.Get(ctx, obj.GetName(), metav1.GetOptions{})
-		// Avoid modifying the existing object from the cache
-		existing = existing.DeepCopy()
 
 		// If the object doesn't exist or there's an error other than "not found", proceed with patching
 		switch {
@@ -292,23 +552,8 @@ func (d *Deployer) DeployObjsWithSource(ctx context.Context, objs []client.Objec
 			if err := d.validateExistingServiceOwnership(sourceObj, obj, existing); err != nil {
 				return err
 			}
-			// zero out fields that api server changes
-			existing.SetResourceVersion("")
-			existing.SetGeneration(0)
-			existing.SetUID("")
-			existing.SetCreationTimestamp(metav1.Time{})
-			existing.SetDeletionTimestamp(nil)
-			existing.SetDeletionGracePeriodSeconds(nil)
-			existing.SetManagedFields(nil)
-			// clear the status from existing object. Uses SetNestedField if u.Object["status"] exists
-			// to ensure they are equal
-			if v, ok := u.Object["status"]; ok {
-				unstructured.SetNestedField(existing.Object, v, "status")
-			} else {
-				unstructured.RemoveNestedField(existing.Object, "status")
-			}
 			// Check if the objects are equal - if they are, skip the patch
-			if equality.Semantic.DeepEqual(u, existing) {
+			if !objectChanged(u, existing) {
 				logger.Debug("object unchanged, skipping apply",
 					"kind", obj.GetObjectKind().GroupVersionKind().String(),
 					"namespace", obj.GetNamespace(),
@@ -339,6 +584,30 @@ func (d *Deployer) DeployObjsWithSource(ctx context.Context, objs []client.Objec
 	return nil
 }
 
+// objectChanged reports whether desired differs from existing, ignoring the fields that the
+// API server populates. existing is not modified. Fields the API server defaults (e.g. a
+// Deployment's revisionHistoryLimit) count as changes, since they cannot be told apart from a
+// field the desired object no longer sets.
+func objectChanged(desired, existing *unstructured.Unstructured) bool {
+	existing = existing.DeepCopy()
+	// zero out fields that api server changes
+	existing.SetResourceVersion("")
+	existing.SetGeneration(0)
+	existing.SetUID("")
+	existing.SetCreationTimestamp(metav1.Time{})
+	existing.SetDeletionTimestamp(nil)
+	existing.SetDeletionGracePeriodSeconds(nil)
+	existing.SetManagedFields(nil)
+	// clear the status from existing object. Uses SetNestedField if desired.Object["status"] exists
+	// to ensure they are equal
+	if v, ok := desired.Object["status"]; ok {
+		unstructured.SetNestedField(existing.Object, v, "status")
+	} else {
+		unstructured.RemoveNestedField(existing.Object, "status")
+	}
+	return !equality.Semantic.DeepEqual(desired, existing)
+}
+
 func (d *Deployer) validateExistingServiceOwnership(sourceObj, desiredObj client.Object, existingObj *unstructured.Unstructured) error {
 	if sourceObj == nil || desiredObj.GetObjectKind().GroupVersionKind() != wellknown.ServiceGVK {
 		return nil
@@ -500,10 +769,12 @@ func ConvertYAMLToObjects(scheme *runtime.Scheme, yamlData []byte) ([]client.Obj
 	return objs, nil
 }
 
-// PruneRemovedResources deletes PDB/HPA/VPA resources that are owned by the
-// owner but are no longer in the desired set of objects. This prevents stale
-// resources from persisting when configuration changes. ownerReferences is
-// insufficient because the owner might still exist.
+// PruneRemovedResources deletes PDB/HPA/VPA resources and objects of contributed kinds
+// that are owned by the owner but are no longer in the desired set of objects. This
+// prevents stale resources from persisting when configuration changes. ownerReferences
+// is insufficient because the owner might still exist. Objects of contributed kinds are
+// only pruned if the owner is also their controller, so that objects created by users that
+// happen to carry the gateway name label are left alone.
 func (d *Deployer) PruneRemovedResources(ctx context.Context, owner client.Object, desiredObjs []client.Object) error {
 	ownerNamespace := owner.GetNamespace()
 	// Kubernetes label values are limited to 63 characters, but Gateway names can exceed this limit.
@@ -524,14 +795,24 @@ func (d *Deployer) PruneRemovedResources(ctx context.Context, owner client.Objec
 		wellknown.HorizontalPodAutoscalerGVK,
 		wellknown.VerticalPodAutoscalerGVK,
 	}
+	builtinGVKs := len(targetGVKs)
+	for _, gvk := range d.contributedKinds() {
+		if !slices.Contains(targetGVKs, gvk) {
+			targetGVKs = append(targetGVKs, gvk)
+		}
+	}
 	var pruningErrors []error
-	for _, gvk := range targetGVKs {
+	for i, gvk := range targetGVKs {
+		contributed := i >= builtinGVKs
 		gvr, err := d.gvkToGVR(gvk)
 		if err != nil {
 			logger.Debug("skipping pruning for unknown GVK", "gvk", gvk.String(), "error", err)
 			continue
 		}
-		client := d.client.Dynamic().Resource(gvr).Namespace(ownerNamespace)
+		var client dynamic.ResourceInterface = d.client.Dynamic().Resource(gvr)
+		if kubeutils.IsNamespacedGVK(gvk) {
+			client = d.client.Dynamic().Resource(gvr).Namespace(ownerNamespace)
+		}
 		list, err := client.List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
 		if err != nil {
 			if apierrors.IsNotFound(err) {
@@ -546,6 +827,9 @@ func (d *Deployer) PruneRemovedResources(ctx context.Context, owner client.Objec
 			if desiredSet, exists := desiredByGVK[gvk]; exists && desiredSet[resourceName] {
 				continue
 			}
+			if contributed && !hasControllerOwnerRef(&item, owner) {
+				continue
+			}
 			logger.Info("pruning removed resource",
 				"gvk", gvk.String(),
 				"namespace", ownerNamespace,
@@ -2,8 +2,11 @@ package deployer_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
@@ -16,6 +19,7 @@ import (
 	"istio.io/istio/pkg/config/schema/gvk"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -62,6 +66,43 @@ func unmarshalYaml(data []byte, into proto.Message) error {
 	return j.Unmarshal(jsn, into)
 }
 
+// configMapContributor contributes a ConfigMap named after the Gateway.
+type configMapContributor struct{}
+
+func (configMapContributor) ContributeObjects(_ context.Context, obj client.Object) ([]client.Object, error) {
+	return []client.Object{
+		// built without TypeMeta, as typed objects usually are
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: obj.GetName() + "-contributed",
+			},
+			Data: map[string]string{"gateway": obj.GetName()},
+		},
+	}, nil
+}
+
+func (configMapContributor) ContributedKinds() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{wellknown.ConfigMapGVK}
+}
+
+// widgetContributor declares a kind that cannot be mapped to a resource.
+type widgetContributor struct{}
+
+func (widgetContributor) ContributeObjects(context.Context, client.Object) ([]client.Object, error) {
+	return nil, nil
+}
+
+func (widgetContributor) ContributedKinds() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{{Group: "example.com", Version: "v1", Kind: "Widget"}}
+}
+
+// postProcessorFunc adapts a function to deployer.ObjectPostProcessor.
+type postProcessorFunc func(ctx context.Context, obj client.Object, rendered []client.Object) ([]client.Object, error)
+
+func (f postProcessorFunc) PostProcessObjects(ctx context.Context, obj client.Object, rendered []client.Object) ([]client.Object, error) {
+	return f(ctx, obj, rendered)
+}
+
 type clientObjects []client.Object
 
 func (objs *clientObjects) findDeployment(name string) *appsv1.Deployment {
@@ -286,6 +327,47 @@ var _ = Describe("Deployer", func() {
 				},
 			}
 		}
+
+		// newGatewayDeployer creates a Gateway deployer with the given options, backed by a fake
+		// client holding gwc and gwp (if set), and waits for the fake client to sync.
+		newGatewayDeployer = func(
+			gwc *gwv1.GatewayClass,
+			gw *gwv1.Gateway,
+			gwp *kgateway.GatewayParameters,
+			opts ...deployer.Option,
+		) (*deployer.Deployer, *deployerinternal.GatewayParameters, error) {
+			objs := []client.Object{gwc}
+			if gwp != nil {
+				objs = append(objs, gwp)
+			}
+			fakeClient := fake.NewClient(GinkgoT(), objs...)
+			gwParams := deployerinternal.NewGatewayParameters(fakeClient, &deployer.Inputs{
+				CommonCollections: deployertest.NewCommonCols(GinkgoT(), gwc, gw),
+				ControlPlane: deployer.ControlPlaneInfo{
+					XdsHost: "something.cluster.local",
+					XdsPort: 1234,
+				},
+				ImageInfo: &deployer.ImageInfo{
+					Registry: "foo",
+					Tag:      "bar",
+				},
+				GatewayClassName:         wellknown.DefaultGatewayClassName,
+				WaypointGatewayClassName: wellknown.DefaultWaypointClassName,
+			})
+			d, err := deployerinternal.NewGatewayDeployer(wellknown.DefaultGatewayControllerName, scheme, fakeClient, gwParams, opts...)
+			if err != nil {
+				return nil, nil, err
+			}
+			fakeClient.RunAndWait(context.Background().Done())
+			return d, gwParams, nil
+		}
+
+		// objsToDeploy returns the objects d deploys for gw, with their namespace and owner set.
+		objsToDeploy = func(d *deployer.Deployer, gw *gwv1.Gateway) clientObjects {
+			objs, err := d.GetObjsToDeploy(context.Background(), gw)
+			Expect(err).NotTo(HaveOccurred())
+			return d.SetNamespaceAndOwner(gw, objs)
+		}
 	)
 
 	Context("default case", func() {
@@ -903,6 +985,302 @@ var _ = Describe("Deployer", func() {
 		})
 	})
 
+	Context("overlays", func() {
+		It("applies deployment overlays to the pod template before it is deployed", func() {
+			// deployDeployment deploys the objects rendered for gwp and returns the Deployment
+			// that was sent to the API server.
+			deployDeployment := func(gwp *kgateway.GatewayParameters) *appsv1.Deployment {
+				var applied *appsv1.Deployment
+				recordDeployment := func(_ context.Context, _ apiclient.Client, _ string, gvr schema.GroupVersionResource, _ string, _ string, data []byte, _ ...string) error {
+					if gvr.Resource != "deployments" {
+						return nil
+					}
+					applied = &appsv1.Deployment{}
+					return json.Unmarshal(data, applied)
+				}
+
+				gw := defaultGateway()
+				d, _, err := newGatewayDeployer(defaultGatewayClassWithParamsRef(), gw, gwp, deployer.WithPatcher(recordDeployment))
+				Expect(err).NotTo(HaveOccurred())
+
+				objs, err := d.GetObjsToDeploy(context.Background(), gw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(d.DeployObjsWithSource(context.Background(), objs, gw)).To(Succeed())
+				Expect(applied).NotTo(BeNil())
+				return applied
+			}
+
+			withOverlay := defaultGatewayParams()
+			withOverlay.Spec.Kube.GatewayParametersOverlays.DeploymentOverlay = &shared.KubernetesResourceOverlay{
+				Spec: &apiextensionsv1.JSON{Raw: []byte(`{
+					"template": {
+						"spec": {
+							"containers": [{
+								"name": "kgateway-proxy",
+								"env": [{"name": "OVERLAY_ENV", "value": "overlay-value"}]
+							}]
+						}
+					}
+				}`)},
+			}
+
+			base := deployDeployment(defaultGatewayParams())
+			patched := deployDeployment(withOverlay)
+
+			Expect(patched.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "OVERLAY_ENV", Value: "overlay-value"}))
+			// The pod-template-hash is computed by Kubernetes from the pod template of the
+			// applied Deployment, so a differing template is what triggers a rollout.
+			Expect(patched.Spec.Template).NotTo(Equal(base.Spec.Template))
+		})
+	})
+
+	Context("object post-processors", func() {
+		newDeployer := func(gwp *kgateway.GatewayParameters, postProcessors ...deployer.ObjectPostProcessor) (*deployer.Deployer, *gwv1.Gateway) {
+			gw := defaultGateway()
+			d, _, err := newGatewayDeployer(defaultGatewayClassWithParamsRef(), gw, gwp, deployer.WithObjectPostProcessors(postProcessors...))
+			Expect(err).NotTo(HaveOccurred())
+			return d, gw
+		}
+
+		It("runs post-processors in order after GatewayParameters overlays", func() {
+			gwp := defaultGatewayParams()
+			gwp.Spec.Kube.GatewayParametersOverlays.DeploymentOverlay = &shared.KubernetesResourceOverlay{
+				Metadata: &shared.ObjectMetadata{
+					Annotations: map[string]string{"overlay": "applied"},
+				},
+			}
+
+			var calls []string
+			annotate := func(name string) deployer.ObjectPostProcessor {
+				return postProcessorFunc(func(_ context.Context, _ client.Object, rendered []client.Object) ([]client.Object, error) {
+					calls = append(calls, name)
+					for _, obj := range rendered {
+						if dep, ok := obj.(*appsv1.Deployment); ok {
+							// the GatewayParameters overlay must already have been applied
+							Expect(dep.Annotations).To(HaveKeyWithValue("overlay", "applied"))
+							dep.Annotations[name] = strings.Join(calls, ",")
+						}
+					}
+					return rendered, nil
+				})
+			}
+			d, gw := newDeployer(gwp, annotate("first"), annotate("second"))
+
+			objs := objsToDeploy(d, gw)
+
+			Expect(calls).To(Equal([]string{"first", "second"}))
+			dep := objs.findDeployment(defaultDeploymentName)
+			Expect(dep).NotTo(BeNil())
+			Expect(dep.Annotations).To(HaveKeyWithValue("first", "first"))
+			Expect(dep.Annotations).To(HaveKeyWithValue("second", "first,second"))
+		})
+
+		It("aborts when a post-processor fails", func() {
+			var secondCalled bool
+			d, gw := newDeployer(defaultGatewayParams(),
+				postProcessorFunc(func(context.Context, client.Object, []client.Object) ([]client.Object, error) {
+					return nil, errors.New("boom")
+				}),
+				postProcessorFunc(func(_ context.Context, _ client.Object, rendered []client.Object) ([]client.Object, error) {
+					secondCalled = true
+					return rendered, nil
+				}),
+			)
+
+			_, err := d.GetObjsToDeploy(context.Background(), gw)
+			Expect(err).To(MatchError(ContainSubstring("boom")))
+			Expect(secondCalled).To(BeFalse())
+		})
+
+		It("fails when the objects to deploy no longer include the Deployment", func() {
+			d, gw := newDeployer(defaultGatewayParams(),
+				postProcessorFunc(func(_ context.Context, _ client.Object, rendered []client.Object) ([]client.Object, error) {
+					var kept []client.Object
+					for _, obj := range rendered {
+						if _, ok := obj.(*appsv1.Deployment); !ok {
+							kept = append(kept, obj)
+						}
+					}
+					return kept, nil
+				}),
+			)
+
+			_, err := d.GetObjsToDeploy(context.Background(), gw)
+			Expect(err).To(MatchError(deployer.ErrMissingRequiredObjects))
+			Expect(err).To(MatchError(ContainSubstring("rendered objects do not include a Deployment")))
+		})
+
+		It("lists every missing required kind", func() {
+			d, gw := newDeployer(defaultGatewayParams(),
+				postProcessorFunc(func(_ context.Context, _ client.Object, rendered []client.Object) ([]client.Object, error) {
+					var kept []client.Object
+					for _, obj := range rendered {
+						switch obj.(type) {
+						case *appsv1.Deployment, *corev1.Service:
+						default:
+							kept = append(kept, obj)
+						}
+					}
+					return kept, nil
+				}),
+			)
+
+			_, err := d.GetObjsToDeploy(context.Background(), gw)
+			Expect(err).To(MatchError(deployer.ErrMissingRequiredObjects))
+			Expect(err).To(MatchError(ContainSubstring("rendered objects do not include a Deployment and a Service")))
+		})
+
+		It("fails with a distinct error when no objects are left to deploy", func() {
+			d, gw := newDeployer(defaultGatewayParams(),
+				postProcessorFunc(func(context.Context, client.Object, []client.Object) ([]client.Object, error) {
+					return nil, nil
+				}),
+			)
+
+			_, err := d.GetObjsToDeploy(context.Background(), gw)
+			Expect(err).To(MatchError(deployer.ErrNoObjectsRendered))
+			Expect(err).NotTo(MatchError(deployer.ErrMissingRequiredObjects))
+		})
+	})
+
+	Context("pod template labels", func() {
+		It("stamps labels derived from the Gateway onto the pod template", func() {
+			protocolLabels := func(obj client.Object) map[string]string {
+				gw, ok := obj.(*gwv1.Gateway)
+				if !ok {
+					return nil
+				}
+				labels := map[string]string{}
+				for _, l := range gw.Spec.Listeners {
+					labels["protocol.example.com/"+strings.ToLower(string(l.Protocol))] = "true"
+				}
+				// selector labels must not be overwritten
+				labels[wellknown.GatewayNameLabel] = "overwritten"
+				return labels
+			}
+
+			gw := defaultGateway()
+			gw.Spec.Listeners[0].Protocol = gwv1.HTTPProtocolType
+			d, _, err := newGatewayDeployer(defaultGatewayClassWithParamsRef(), gw, defaultGatewayParams(),
+				deployer.WithPodTemplateLabels(protocolLabels))
+			Expect(err).NotTo(HaveOccurred())
+
+			objs := objsToDeploy(d, gw)
+			dep := objs.findDeployment(defaultDeploymentName)
+			Expect(dep).NotTo(BeNil())
+			Expect(dep.Spec.Template.Labels).To(HaveKeyWithValue("protocol.example.com/http", "true"))
+			Expect(dep.Spec.Template.Labels).To(HaveKeyWithValue(wellknown.GatewayNameLabel, gw.Name))
+		})
+	})
+
+	Context("object contributors", func() {
+		It("deploys contributed objects owned by the Gateway", func() {
+			gw := defaultGateway()
+			d, _, err := newGatewayDeployer(defaultGatewayClassWithParamsRef(), gw, defaultGatewayParams(),
+				deployer.WithObjectContributors(configMapContributor{}))
+			Expect(err).NotTo(HaveOccurred())
+
+			objs := objsToDeploy(d, gw)
+			Expect(objs.findDeployment(defaultDeploymentName)).NotTo(BeNil())
+			cm := objs.findConfigMap(defaultNamespace, gw.Name+"-contributed")
+			Expect(cm).NotTo(BeNil())
+			Expect(cm.Data).To(HaveKeyWithValue("gateway", gw.Name))
+			Expect(cm.GroupVersionKind()).To(Equal(wellknown.ConfigMapGVK))
+			Expect(cm.Labels).To(HaveKeyWithValue(wellknown.GatewayNameLabel, gw.Name))
+			ownerRefs := cm.GetOwnerReferences()
+			Expect(ownerRefs).To(HaveLen(1))
+			Expect(ownerRefs[0].Name).To(Equal(gw.Name))
+			Expect(ownerRefs[0].UID).To(Equal(gw.UID))
+			Expect(ownerRefs[0].Kind).To(Equal(gw.Kind))
+		})
+
+		It("rejects contributed kinds that cannot be mapped to a resource", func() {
+			_, _, err := newGatewayDeployer(defaultGatewayClassWithParamsRef(), defaultGateway(), defaultGatewayParams(),
+				deployer.WithObjectContributors(widgetContributor{}))
+			Expect(err).To(MatchError(ContainSubstring("unsupported contributed kind")))
+		})
+	})
+
+	Context("render to directory", func() {
+		It("writes one YAML file per object to deploy", func() {
+			gw := defaultGateway()
+			// stamp owner references and server-populated metadata on the rendered objects, as
+			// they would carry when read back from the cluster, to check they are not exported
+			withServerMetadata := postProcessorFunc(func(_ context.Context, _ client.Object, rendered []client.Object) ([]client.Object, error) {
+				for _, obj := range rendered {
+					obj.SetOwnerReferences([]metav1.OwnerReference{{
+						APIVersion: gwv1.GroupVersion.String(),
+						Kind:       wellknown.GatewayKind,
+						Name:       gw.Name,
+						UID:        gw.UID,
+						Controller: new(true),
+					}})
+					obj.SetUID("5678")
+					obj.SetResourceVersion("42")
+					obj.SetGeneration(3)
+					obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kgateway", Operation: metav1.ManagedFieldsOperationApply}})
+				}
+				return rendered, nil
+			})
+			d, _, err := newGatewayDeployer(defaultGatewayClassWithParamsRef(), gw, defaultGatewayParams(),
+				deployer.WithObjectPostProcessors(withServerMetadata))
+			Expect(err).NotTo(HaveOccurred())
+
+			dir := filepath.Join(GinkgoT().TempDir(), "export")
+			Expect(d.RenderToDir(context.Background(), gw, dir)).To(Succeed())
+
+			objs, err := d.GetObjsToDeploy(context.Background(), gw)
+			Expect(err).NotTo(HaveOccurred())
+			entries, err := os.ReadDir(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(len(objs)))
+			for _, fileName := range []string{
+				"deployment-" + defaultDeploymentName + ".yaml",
+				"service-" + defaultServiceName + ".yaml",
+				"configmap-" + defaultConfigMapName + ".yaml",
+			} {
+				data, err := os.ReadFile(filepath.Join(dir, fileName))
+				Expect(err).NotTo(HaveOccurred(), "expected %s to be written", fileName)
+
+				u := &unstructured.Unstructured{}
+				Expect(yaml.Unmarshal(data, &u.Object)).To(Succeed(), "expected %s to be valid YAML", fileName)
+				Expect(strings.ToLower(u.GetKind()) + "-" + u.GetName() + ".yaml").To(Equal(fileName))
+				Expect(u.GetNamespace()).To(Equal(defaultNamespace))
+				Expect(u.Object).NotTo(HaveKey("status"))
+				metadata, ok := u.Object["metadata"].(map[string]any)
+				Expect(ok).To(BeTrue(), "expected %s to have metadata", fileName)
+				for _, field := range []string{"ownerReferences", "uid", "resourceVersion", "generation", "managedFields", "creationTimestamp"} {
+					Expect(metadata).NotTo(HaveKey(field), "expected %s to omit metadata.%s", fileName, field)
+				}
+			}
+		})
+	})
+
+	Context("image pull policy", func() {
+		It("omits imagePullPolicy when the pull policy is empty", func() {
+			gw := defaultGateway()
+			gwp := defaultGatewayParams()
+			// an empty pull policy means the Kubernetes default
+			gwp.Spec.Kube.EnvoyContainer.Image.PullPolicy = new(corev1.PullPolicy(""))
+			d, gwParams, err := newGatewayDeployer(defaultGatewayClassWithParamsRef(), gw, gwp)
+			Expect(err).NotTo(HaveOccurred())
+
+			vals, err := gwParams.GetValues(context.Background(), gw)
+			Expect(err).NotTo(HaveOccurred())
+			manifest, err := d.RenderManifest(gw.Namespace, gw.Name, vals)
+			Expect(err).NotTo(HaveOccurred())
+			// an empty pull policy must not be rendered, as the API server rejects it
+			Expect(string(manifest)).NotTo(ContainSubstring("imagePullPolicy"))
+
+			objs := objsToDeploy(d, gw)
+			dep := objs.findDeployment(defaultDeploymentName)
+			Expect(dep).NotTo(BeNil())
+			for _, c := range dep.Spec.Template.Spec.Containers {
+				Expect(c.ImagePullPolicy).To(BeEmpty(), "container %s", c.Name)
+			}
+		})
+	})
+
 	Context("Gateway API infrastructure field", func() {
 		It("rejects invalid group in spec.infrastructure.parametersRef", func() {
 			gw := &gwv1.Gateway{
@@ -993,6 +1371,41 @@ var _ = Describe("Deployer", func() {
 			_, err = d.GetObjsToDeploy(context.Background(), gw)
 			Expect(err).To(MatchError(ContainSubstring("invalid kind InvalidKind for GatewayParameters")))
 		})
+
+		It("propagates spec.infrastructure.annotations to the Service and pod template", func() {
+			const lbAnnotation = "service.beta.kubernetes.io/aws-load-balancer-type"
+			gw := &gwv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: defaultNamespace,
+					UID:       "1235",
+				},
+				Spec: gwv1.GatewaySpec{
+					GatewayClassName: wellknown.DefaultGatewayClassName,
+					Infrastructure: &gwv1.GatewayInfrastructure{
+						Annotations: map[gwv1.AnnotationKey]gwv1.AnnotationValue{
+							lbAnnotation: "nlb",
+						},
+					},
+					Listeners: []gwv1.Listener{{
+						Name: "listener-1",
+						Port: 80,
+					}},
+				},
+			}
+
+			d, _, err := newGatewayDeployer(defaultGatewayClass(), gw, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			objs := objsToDeploy(d, gw)
+			svc := objs.findService(gw.Name)
+			Expect(svc).NotTo(BeNil())
+			Expect(svc.Annotations).To(HaveKeyWithValue(lbAnnotation, "nlb"))
+
+			dep := objs.findDeployment(gw.Name)
+			Expect(dep).NotTo(BeNil())
+			Expect(dep.Spec.Template.Annotations).To(HaveKeyWithValue(lbAnnotation, "nlb"))
+		})
 	})
 
 	Context("defaulting", func() {
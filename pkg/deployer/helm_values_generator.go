@@ -3,6 +3,7 @@ package deployer
 import (
 	"context"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -21,7 +22,8 @@ type HelmValuesGenerator interface {
 
 // ObjectPostProcessor is an optional interface that can be implemented by HelmValuesGenerator
 // to post-process rendered objects before they are deployed. This is used for applying
-// strategic merge patch overlays from GatewayParameters.
+// strategic merge patch overlays from GatewayParameters. Additional post-processors can be
+// registered with WithObjectPostProcessors.
 type ObjectPostProcessor interface {
 	// PostProcessObjects applies any post-processing to the rendered objects.
 	// This is called after helm rendering but before deployment.
@@ -29,3 +31,27 @@ type ObjectPostProcessor interface {
 	// (e.g., PodDisruptionBudget, HorizontalPodAutoscaler).
 	PostProcessObjects(ctx context.Context, obj client.Object, rendered []client.Object) ([]client.Object, error)
 }
+
+// ObjectContributor contributes additional objects to be deployed alongside the rendered
+// chart for the given object (e.g. a Secret or ConfigMap generated for a Gateway).
+// Contributed objects are deployed and owned in the same way as rendered objects, and are
+// pruned once they are no longer contributed.
+type ObjectContributor interface {
+	// ContributeObjects returns the additional objects to deploy for the given object.
+	// It is called after helm rendering and post-processing. Typed objects need not set
+	// TypeMeta; their kind is resolved through the deployer's scheme.
+	ContributeObjects(ctx context.Context, obj client.Object) ([]client.Object, error)
+
+	// ContributedKinds returns the kinds of objects ContributeObjects may return. Kinds the
+	// deployer cannot map to a resource are reported by Deployer.ValidateContributedKinds, and
+	// objects of these kinds that are no longer contributed are pruned.
+	ContributedKinds() []schema.GroupVersionKind
+}
+
+// ParametersRefProvider is an optional interface that can be implemented by a HelmValuesGenerator
+// override or a gateway controller extension that resolves its own GatewayClass parametersRef kinds.
+// The returned group/kinds are accepted in addition to GatewayParameters.
+type ParametersRefProvider interface {
+	// SupportedParametersRefs returns the group/kinds this implementation accepts in a GatewayClass parametersRef.
+	SupportedParametersRefs() []schema.GroupKind
+}
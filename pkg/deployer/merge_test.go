@@ -64,6 +64,45 @@ func TestDeepMergeGatewayParameters(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "should compose class-level image registry with gateway-level image tag",
+			dst: &kgateway.GatewayParameters{
+				Spec: kgateway.GatewayParametersSpec{
+					Kube: &kgateway.KubernetesProxyConfig{
+						EnvoyContainer: &kgateway.EnvoyContainer{
+							Image: &kgateway.Image{
+								Registry: new("registry.example.com/mirror"),
+							},
+						},
+					},
+				},
+			},
+			src: &kgateway.GatewayParameters{
+				Spec: kgateway.GatewayParametersSpec{
+					Kube: &kgateway.KubernetesProxyConfig{
+						EnvoyContainer: &kgateway.EnvoyContainer{
+							Image: &kgateway.Image{
+								Tag: new("v2.1.0"),
+							},
+						},
+					},
+				},
+			},
+			want: &kgateway.GatewayParameters{
+				Spec: kgateway.GatewayParametersSpec{
+					Kube: &kgateway.KubernetesProxyConfig{
+						EnvoyContainer: &kgateway.EnvoyContainer{
+							Image: &kgateway.Image{
+								Registry: new("registry.example.com/mirror"),
+								Tag:      new("v2.1.0"),
+								// a non-empty tag without a digest clears any inherited digest
+								Digest: new(""),
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "should override kube deployment replicas if explicit",
 			dst: &kgateway.GatewayParameters{
@@ -0,0 +1,117 @@
+package deployer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/utils/kubeutils"
+)
+
+func TestObjectChanged(t *testing.T) {
+	newDeployment := func(replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       wellknown.DeploymentGVK.Kind,
+				APIVersion: wellknown.DeploymentGVK.GroupVersion().String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "gw",
+				Namespace: "test-ns",
+				Labels:    map[string]string{"app": "gw"},
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: new(replicas),
+			},
+		}
+	}
+	// withServerFields sets the fields populated by the API server, which must be ignored
+	withServerFields := func(dep *appsv1.Deployment) *appsv1.Deployment {
+		dep.ResourceVersion = "12345"
+		dep.Generation = 3
+		dep.UID = types.UID("a-uid")
+		dep.CreationTimestamp = metav1.Now()
+		dep.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kgateway"}}
+		dep.Status = appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: 1}
+		return dep
+	}
+	// withServerDefaults sets fields the API server defaults on a Deployment it was not given
+	withServerDefaults := func(dep *appsv1.Deployment) *appsv1.Deployment {
+		dep.Annotations = map[string]string{"deployment.kubernetes.io/revision": "1"}
+		dep.Spec.RevisionHistoryLimit = new(int32(10))
+		dep.Spec.ProgressDeadlineSeconds = new(int32(600))
+		return dep
+	}
+
+	tests := []struct {
+		name     string
+		desired  *appsv1.Deployment
+		existing *appsv1.Deployment
+		expected bool
+	}{
+		{
+			name:     "server populated fields are ignored",
+			desired:  newDeployment(1),
+			existing: withServerFields(newDeployment(1)),
+			expected: false,
+		},
+		{
+			name:     "changed spec is reported",
+			desired:  newDeployment(2),
+			existing: withServerFields(newDeployment(1)),
+			expected: true,
+		},
+		{
+			name:     "server defaulted fields are reported",
+			desired:  newDeployment(1),
+			existing: withServerDefaults(withServerFields(newDeployment(1))),
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired, err := kubeutils.ToUnstructured(tt.desired)
+			require.NoError(t, err)
+			existing, err := kubeutils.ToUnstructured(tt.existing)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, objectChanged(desired, existing))
+		})
+	}
+}
+
+func TestObjectChangedDoesNotModifyExisting(t *testing.T) {
+	live := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       wellknown.DeploymentGVK.Kind,
+			APIVersion: wellknown.DeploymentGVK.GroupVersion().String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "gw",
+			Namespace:       "test-ns",
+			ResourceVersion: "12345",
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+	}
+	desired := live.DeepCopy()
+	desired.ResourceVersion = ""
+	desired.Status = appsv1.DeploymentStatus{}
+
+	// existing objects come from the informer cache and must not be modified
+	desiredU, err := kubeutils.ToUnstructured(desired)
+	require.NoError(t, err)
+	existing, err := kubeutils.ToUnstructured(live)
+	require.NoError(t, err)
+
+	assert.False(t, objectChanged(desiredU, existing))
+	assert.Equal(t, "12345", existing.GetResourceVersion())
+	readyReplicas, _, _ := unstructured.NestedInt64(existing.Object, "status", "readyReplicas")
+	assert.Equal(t, int64(1), readyReplicas)
+}
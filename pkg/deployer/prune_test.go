@@ -2,13 +2,17 @@ package deployer
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"testing"
 
 	"istio.io/istio/pkg/kube"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -27,6 +31,17 @@ func init() {
 	)
 }
 
+// configMapKindContributor declares ConfigMaps as a contributed kind.
+type configMapKindContributor struct{}
+
+func (configMapKindContributor) ContributeObjects(context.Context, client.Object) ([]client.Object, error) {
+	return nil, nil
+}
+
+func (configMapKindContributor) ContributedKinds() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{wellknown.ConfigMapGVK}
+}
+
 func TestPruneRemovedResources(t *testing.T) {
 	var (
 		ns         = "test-ns"
@@ -266,6 +281,97 @@ func TestPruneRemovedResources(t *testing.T) {
 		}
 	})
 
+	configMap := func(namespace, name string, owner *gwv1.Gateway) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					wellknown.GatewayNameLabel: gwName,
+				},
+			},
+		}
+		if owner != nil {
+			cm.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: wellknown.GatewayGVK.GroupVersion().String(),
+				Kind:       wellknown.GatewayGVK.Kind,
+				Name:       owner.Name,
+				UID:        owner.UID,
+				Controller: new(true),
+			}}
+		}
+		return cm
+	}
+
+	t.Run("prunes contributed kinds no longer contributed", func(t *testing.T) {
+		gw := createGateway()
+		gw.UID = "gw-uid"
+		configMap := func(name string) *corev1.ConfigMap {
+			return configMap(ns, name, gw)
+		}
+
+		fc := fake.NewClient(t, gw, configMap("stale"), configMap("kept"))
+		d := &Deployer{client: fc, objectContributors: []ObjectContributor{configMapKindContributor{}}}
+
+		err := d.PruneRemovedResources(ctx, gw, []client.Object{configMap("kept")})
+		if err != nil {
+			t.Fatalf("PruneRemovedResources returned error: %v", err)
+		}
+
+		list, err := fc.Dynamic().Resource(corev1.SchemeGroupVersion.WithResource("configmaps")).Namespace(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("failed to list ConfigMaps: %v", err)
+		}
+		if len(list.Items) != 1 || list.Items[0].GetName() != "kept" {
+			t.Errorf("expected only the kept ConfigMap to remain, got %v", list.Items)
+		}
+	})
+
+	t.Run("prunes only contributed objects controlled by the Gateway", func(t *testing.T) {
+		gw := createGateway()
+		gw.UID = "gw-uid"
+		otherNs := "other-ns"
+		otherGw := createGateway()
+		otherGw.Namespace = otherNs
+		otherGw.UID = "other-gw-uid"
+
+		fc := fake.NewClient(t, gw, otherGw,
+			configMap(ns, "stale", gw),
+			configMap(ns, "user-labelled", nil),
+			configMap(otherNs, "stale", otherGw),
+			configMap(ns, "other-owner", otherGw),
+		)
+		d := &Deployer{client: fc, objectContributors: []ObjectContributor{configMapKindContributor{}}}
+
+		err := d.PruneRemovedResources(ctx, gw, []client.Object{})
+		if err != nil {
+			t.Fatalf("PruneRemovedResources returned error: %v", err)
+		}
+
+		gvr := corev1.SchemeGroupVersion.WithResource("configmaps")
+		list, err := fc.Dynamic().Resource(gvr).Namespace(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("failed to list ConfigMaps: %v", err)
+		}
+		var remaining []string
+		for _, item := range list.Items {
+			remaining = append(remaining, item.GetName())
+		}
+		slices.Sort(remaining)
+		if !slices.Equal(remaining, []string{"other-owner", "user-labelled"}) {
+			t.Errorf("expected only ConfigMaps not controlled by the Gateway to remain, got %v", remaining)
+		}
+
+		otherList, err := fc.Dynamic().Resource(gvr).Namespace(otherNs).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("failed to list ConfigMaps: %v", err)
+		}
+		if len(otherList.Items) != 1 {
+			t.Errorf("expected the same-named Gateway's ConfigMap to remain, got %v", otherList.Items)
+		}
+	})
+
 	t.Run("handles no existing resources gracefully", func(t *testing.T) {
 		gw := createGateway()
 
@@ -422,3 +528,27 @@ func TestPruneRemovedResourcesLongGatewayName(t *testing.T) {
 		}
 	})
 }
+
+// namespaceKindContributor declares Namespaces, which are cluster-scoped, as a contributed kind.
+type namespaceKindContributor struct{}
+
+func (namespaceKindContributor) ContributeObjects(context.Context, client.Object) ([]client.Object, error) {
+	return nil, nil
+}
+
+func (namespaceKindContributor) ContributedKinds() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{corev1.SchemeGroupVersion.WithKind("Namespace")}
+}
+
+func TestValidateContributedKindsRejectsClusterScopedKinds(t *testing.T) {
+	d := &Deployer{client: fake.NewClient(t), objectContributors: []ObjectContributor{namespaceKindContributor{}}}
+	err := d.ValidateContributedKinds()
+	if err == nil || !strings.Contains(err.Error(), "cluster-scoped") {
+		t.Fatalf("expected cluster-scoped kind to be rejected, got %v", err)
+	}
+
+	d = &Deployer{client: fake.NewClient(t), objectContributors: []ObjectContributor{configMapKindContributor{}}}
+	if err := d.ValidateContributedKinds(); err != nil {
+		t.Fatalf("expected namespaced kind to be accepted, got %v", err)
+	}
+}
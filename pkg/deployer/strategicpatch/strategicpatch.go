@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"maps"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -65,6 +66,7 @@ func NewOverlayApplierFromOverlays(overlays *ResourceOverlays) *OverlayApplier {
 // ApplyOverlays applies the overlays to the rendered objects.
 // It modifies the objects in place and may append new objects (PDB, HPA, VPA) to the slice.
 // The caller must use the returned slice as the objects list may grow.
+// Applying the same overlays more than once yields the same objects as applying them once.
 func (a *OverlayApplier) ApplyOverlays(objs []client.Object) ([]client.Object, error) {
 	if a.overlays == nil {
 		return objs, nil
@@ -79,31 +81,35 @@ func (a *OverlayApplier) ApplyOverlays(objs []client.Object) ([]client.Object, e
 		}
 	}
 
+	// Track which generated kinds are already present so they are patched in place
+	// rather than generated from the Deployment.
+	var hasPDB, hasHPA, hasVPA bool
 	for i, obj := range objs {
-		var overlay *shared.KubernetesResourceOverlay
-		var gvk schema.GroupVersionKind
-
-		// Use type assertions to determine the object type, as GVK may not be set
-		// on typed structs rendered from Helm charts
-		switch obj.(type) {
-		case *appsv1.Deployment:
-			overlay = a.overlays.Deployment
-			gvk = wellknown.DeploymentGVK
-		case *corev1.Service:
-			overlay = a.overlays.Service
-			gvk = wellknown.ServiceGVK
-		case *corev1.ServiceAccount:
-			overlay = a.overlays.ServiceAccount
-			gvk = wellknown.ServiceAccountGVK
-		default:
+		overlay, gvk := a.overlayFor(obj)
+		if gvk.Empty() {
 			continue
 		}
+		switch gvk {
+		case wellknown.PodDisruptionBudgetGVK:
+			hasPDB = true
+		case wellknown.HorizontalPodAutoscalerGVK:
+			hasHPA = true
+		case wellknown.VerticalPodAutoscalerGVK:
+			hasVPA = true
+		}
 
 		if overlay == nil {
 			continue
 		}
 
-		patched, err := applyOverlay(obj, overlay, gvk)
+		var patched client.Object
+		var err error
+		if vpa, ok := obj.(*unstructured.Unstructured); ok {
+			err = applyVerticalPodAutoscalerOverlay(vpa, overlay)
+			patched = vpa
+		} else {
+			patched, err = applyOverlay(obj, overlay, gvk)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to apply overlay to %s/%s: %w", gvk.Kind, obj.GetName(), err)
 		}
@@ -111,7 +117,7 @@ func (a *OverlayApplier) ApplyOverlays(objs []client.Object) ([]client.Object, e
 	}
 
 	// Create PDB if overlay is present
-	if a.overlays.PodDisruptionBudget != nil && deployment != nil {
+	if a.overlays.PodDisruptionBudget != nil && deployment != nil && !hasPDB {
 		pdb, err := createPodDisruptionBudget(deployment, a.overlays.PodDisruptionBudget)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create PodDisruptionBudget: %w", err)
@@ -120,7 +126,7 @@ func (a *OverlayApplier) ApplyOverlays(objs []client.Object) ([]client.Object, e
 	}
 
 	// Create HPA if overlay is present
-	if a.overlays.HorizontalPodAutoscaler != nil && deployment != nil {
+	if a.overlays.HorizontalPodAutoscaler != nil && deployment != nil && !hasHPA {
 		hpa, err := createHorizontalPodAutoscaler(deployment, a.overlays.HorizontalPodAutoscaler)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create HorizontalPodAutoscaler: %w", err)
@@ -129,7 +135,7 @@ func (a *OverlayApplier) ApplyOverlays(objs []client.Object) ([]client.Object, e
 	}
 
 	// Create VPA if overlay is present
-	if a.overlays.VerticalPodAutoscaler != nil && deployment != nil {
+	if a.overlays.VerticalPodAutoscaler != nil && deployment != nil && !hasVPA {
 		vpa, err := createVerticalPodAutoscaler(deployment, a.overlays.VerticalPodAutoscaler)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create VerticalPodAutoscaler: %w", err)
@@ -140,6 +146,30 @@ func (a *OverlayApplier) ApplyOverlays(objs []client.Object) ([]client.Object, e
 	return objs, nil
 }
 
+// overlayFor returns the overlay configured for the kind of obj along with its GVK.
+// The GVK is empty for kinds that do not support overlays, which are left untouched.
+func (a *OverlayApplier) overlayFor(obj client.Object) (*shared.KubernetesResourceOverlay, schema.GroupVersionKind) {
+	// Use type assertions to determine the object type, as GVK may not be set
+	// on typed structs rendered from Helm charts
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return a.overlays.Deployment, wellknown.DeploymentGVK
+	case *corev1.Service:
+		return a.overlays.Service, wellknown.ServiceGVK
+	case *corev1.ServiceAccount:
+		return a.overlays.ServiceAccount, wellknown.ServiceAccountGVK
+	case *policyv1.PodDisruptionBudget:
+		return a.overlays.PodDisruptionBudget, wellknown.PodDisruptionBudgetGVK
+	case *autoscalingv2.HorizontalPodAutoscaler:
+		return a.overlays.HorizontalPodAutoscaler, wellknown.HorizontalPodAutoscalerGVK
+	case *unstructured.Unstructured:
+		if o.GroupVersionKind() == wellknown.VerticalPodAutoscalerGVK {
+			return a.overlays.VerticalPodAutoscaler, wellknown.VerticalPodAutoscalerGVK
+		}
+	}
+	return nil, schema.GroupVersionKind{}
+}
+
 // applyOverlay applies a KubernetesResourceOverlay to a single object.
 func applyOverlay(obj client.Object, overlay *shared.KubernetesResourceOverlay, gvk schema.GroupVersionKind) (client.Object, error) {
 	// Apply metadata first
@@ -164,12 +194,65 @@ func applyOverlay(obj client.Object, overlay *shared.KubernetesResourceOverlay,
 
 	// Apply spec overlay using strategic merge patch if present
 	if overlay.Spec != nil && len(overlay.Spec.Raw) > 0 {
+		if err := validateSpecOverlay(overlay.Spec.Raw); err != nil {
+			return nil, err
+		}
+		if pdb, ok := obj.(*policyv1.PodDisruptionBudget); ok {
+			if err := clearDisruptionBudgetOverriddenBy(pdb, overlay.Spec.Raw); err != nil {
+				return nil, err
+			}
+		}
 		return applySpecOverlay(obj, overlay.Spec.Raw, gvk)
 	}
 
 	return obj, nil
 }
 
+// validateSpecOverlay checks that a spec overlay is a JSON object. The CRD schema types the spec
+// as an object, but a non-object value would otherwise surface as a confusing merge failure.
+func validateSpecOverlay(raw []byte) error {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return fmt.Errorf("invalid spec overlay: %w", err)
+	}
+	if _, ok := value.(map[string]any); ok {
+		return nil
+	}
+	var kind string
+	switch value.(type) {
+	case []any:
+		kind = "array"
+	case string:
+		kind = "string"
+	case float64:
+		kind = "number"
+	case bool:
+		kind = "boolean"
+	default:
+		kind = "null"
+	}
+	return fmt.Errorf("invalid spec overlay: must be a JSON object, got %s", kind)
+}
+
+// clearDisruptionBudgetOverriddenBy clears minAvailable or maxUnavailable on the PDB when the spec
+// overlay sets only the other one. The API server rejects a PDB with both fields set, so an overlay
+// from the Gateway that picks one of them replaces the choice made by the GatewayClass overlay.
+func clearDisruptionBudgetOverriddenBy(pdb *policyv1.PodDisruptionBudget, raw []byte) error {
+	var spec map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return fmt.Errorf("invalid spec overlay: %w", err)
+	}
+	_, setsMinAvailable := spec["minAvailable"]
+	_, setsMaxUnavailable := spec["maxUnavailable"]
+	switch {
+	case setsMinAvailable && !setsMaxUnavailable:
+		pdb.Spec.MaxUnavailable = nil
+	case setsMaxUnavailable && !setsMinAvailable:
+		pdb.Spec.MinAvailable = nil
+	}
+	return nil
+}
+
 // applySpecOverlay applies a spec overlay using strategic merge patch semantics.
 func applySpecOverlay(obj client.Object, patchBytes []byte, gvk schema.GroupVersionKind) (client.Object, error) {
 	// Get the schema for strategic merge patch
@@ -343,6 +426,15 @@ func createVerticalPodAutoscaler(deployment *appsv1.Deployment, overlay *shared.
 	vpa.SetLabels(maps.Clone(deployment.GetLabels()))
 
 	// Apply the overlay - for VPA we need to handle it specially since it's unstructured
+	if err := applyVerticalPodAutoscalerOverlay(vpa, overlay); err != nil {
+		return nil, err
+	}
+
+	return vpa, nil
+}
+
+// applyVerticalPodAutoscalerOverlay applies a KubernetesResourceOverlay to an unstructured VPA in place.
+func applyVerticalPodAutoscalerOverlay(vpa *unstructured.Unstructured, overlay *shared.KubernetesResourceOverlay) error {
 	if overlay.Metadata != nil {
 		if overlay.Metadata.Labels != nil {
 			existingLabels := vpa.GetLabels()
@@ -364,23 +456,31 @@ func createVerticalPodAutoscaler(deployment *appsv1.Deployment, overlay *shared.
 
 	// Apply spec overlay if present
 	if overlay.Spec != nil && len(overlay.Spec.Raw) > 0 {
-		// Parse the spec overlay
-		var specPatch map[string]any
-		if err := json.Unmarshal(overlay.Spec.Raw, &specPatch); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal spec patch: %w", err)
+		if err := validateSpecOverlay(overlay.Spec.Raw); err != nil {
+			return err
 		}
-
-		// Merge the spec patch into the VPA spec
+		// VPA has no Go type to derive strategic merge patch metadata from, so the spec overlay
+		// is merged into the existing spec using JSON merge patch semantics.
 		existingSpec, _, _ := unstructured.NestedMap(vpa.Object, "spec")
 		if existingSpec == nil {
 			existingSpec = make(map[string]any)
 		}
-		// Deep merge the patch into existing spec
-		maps.Copy(existingSpec, specPatch)
-		if err := unstructured.SetNestedMap(vpa.Object, existingSpec, "spec"); err != nil {
-			return nil, fmt.Errorf("failed to set VPA spec: %w", err)
+		existingSpecBytes, err := json.Marshal(existingSpec)
+		if err != nil {
+			return fmt.Errorf("failed to marshal VPA spec: %w", err)
+		}
+		mergedSpecBytes, err := jsonpatch.MergePatch(existingSpecBytes, overlay.Spec.Raw)
+		if err != nil {
+			return fmt.Errorf("failed to apply merge patch: %w", err)
+		}
+		var mergedSpec map[string]any
+		if err := json.Unmarshal(mergedSpecBytes, &mergedSpec); err != nil {
+			return fmt.Errorf("failed to unmarshal merged VPA spec: %w", err)
+		}
+		if err := unstructured.SetNestedMap(vpa.Object, mergedSpec, "spec"); err != nil {
+			return fmt.Errorf("failed to set VPA spec: %w", err)
 		}
 	}
 
-	return vpa, nil
+	return nil
 }
@@ -12,6 +12,8 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
@@ -239,6 +241,188 @@ func TestOverlayApplier_ApplyOverlays_DeleteContainerWithPatchDirective(t *testi
 	assert.Equal(t, "kgateway-proxy", result.Spec.Template.Spec.Containers[0].Name)
 }
 
+func TestOverlayApplier_ApplyOverlays_ReplaceContainersWithPatchDirective(t *testing.T) {
+	// Test strategic merge patch with $patch: replace directive on the containers list
+	specPatch := []byte(`{
+		"template": {
+			"spec": {
+				"containers": [
+					{"$patch": "replace"},
+					{"name": "replacement", "image": "replacement:latest"}
+				]
+			}
+		}
+	}`)
+
+	params := &kgateway.GatewayParameters{
+		Spec: kgateway.GatewayParametersSpec{
+			Kube: &kgateway.KubernetesProxyConfig{
+				GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+					DeploymentOverlay: &shared.KubernetesResourceOverlay{
+						Spec: &apiextensionsv1.JSON{Raw: specPatch},
+					},
+				},
+			},
+		},
+	}
+
+	applier := NewOverlayApplierFromGatewayParameters(params)
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-deployment",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "kgateway-proxy",
+							Image: "foo/envoy-wrapper:latest",
+							Env:   []corev1.EnvVar{{Name: "ENVOY_UID", Value: "0"}},
+						},
+						{
+							Name:  "sidecar",
+							Image: "sidecar:latest",
+						},
+					},
+				},
+			},
+		},
+	}
+	objs := []client.Object{deployment}
+
+	objs, err := applier.ApplyOverlays(objs)
+	require.NoError(t, err)
+
+	result := objs[0].(*appsv1.Deployment)
+	assert.Equal(t, []corev1.Container{
+		{Name: "replacement", Image: "replacement:latest"},
+	}, result.Spec.Template.Spec.Containers)
+}
+
+func TestOverlayApplier_ApplyOverlays_DeleteEnvVarWithPatchDirective(t *testing.T) {
+	// Test strategic merge patch with $patch: delete directive on a single env var,
+	// matched by its merge key (name)
+	specPatch := []byte(`{
+		"template": {
+			"spec": {
+				"containers": [{
+					"name": "kgateway-proxy",
+					"env": [{"name": "LOG_FORMAT", "$patch": "delete"}]
+				}]
+			}
+		}
+	}`)
+
+	params := &kgateway.GatewayParameters{
+		Spec: kgateway.GatewayParametersSpec{
+			Kube: &kgateway.KubernetesProxyConfig{
+				GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+					DeploymentOverlay: &shared.KubernetesResourceOverlay{
+						Spec: &apiextensionsv1.JSON{Raw: specPatch},
+					},
+				},
+			},
+		},
+	}
+
+	applier := NewOverlayApplierFromGatewayParameters(params)
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-deployment",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "kgateway-proxy",
+							Image: "foo/envoy-wrapper:latest",
+							Env: []corev1.EnvVar{
+								{Name: "ENVOY_UID", Value: "0"},
+								{Name: "LOG_FORMAT", Value: "json"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	objs := []client.Object{deployment}
+
+	objs, err := applier.ApplyOverlays(objs)
+	require.NoError(t, err)
+
+	result := objs[0].(*appsv1.Deployment)
+	require.Len(t, result.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, "foo/envoy-wrapper:latest", result.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []corev1.EnvVar{{Name: "ENVOY_UID", Value: "0"}}, result.Spec.Template.Spec.Containers[0].Env)
+}
+
+func TestOverlayApplier_ApplyOverlays_RejectsNonObjectSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		specPatch   string
+		expectedErr string
+	}{
+		{
+			name:        "array",
+			specPatch:   `[{"replicas": 3}]`,
+			expectedErr: "invalid spec overlay: must be a JSON object, got array",
+		},
+		{
+			name:        "string",
+			specPatch:   `"replicas: 3"`,
+			expectedErr: "invalid spec overlay: must be a JSON object, got string",
+		},
+		{
+			name:        "null",
+			specPatch:   `null`,
+			expectedErr: "invalid spec overlay: must be a JSON object, got null",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &kgateway.GatewayParameters{
+				Spec: kgateway.GatewayParametersSpec{
+					Kube: &kgateway.KubernetesProxyConfig{
+						GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+							DeploymentOverlay: &shared.KubernetesResourceOverlay{
+								Spec: &apiextensionsv1.JSON{Raw: []byte(tt.specPatch)},
+							},
+						},
+					},
+				},
+			}
+
+			applier := NewOverlayApplierFromGatewayParameters(params)
+			deployment := &appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-deployment",
+				},
+			}
+
+			_, err := applier.ApplyOverlays([]client.Object{deployment})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "failed to apply overlay to Deployment/test-deployment")
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
 func TestOverlayApplier_ApplyOverlays_ServiceSpec(t *testing.T) {
 	specPatch := []byte(`{
 		"type": "NodePort"
@@ -343,6 +527,316 @@ func TestOverlayApplier_ApplyOverlays_MultipleObjects(t *testing.T) {
 	assert.Empty(t, cm.Labels)
 }
 
+func TestOverlayApplier_ApplyOverlays_TwoLevelsMergeListByKey(t *testing.T) {
+	envOverlay := func(value string) *kgateway.GatewayParameters {
+		specPatch := []byte(`{
+			"template": {
+				"spec": {
+					"containers": [{
+						"name": "kgateway-proxy",
+						"env": [{"name": "LOG_FORMAT", "value": "` + value + `"}]
+					}]
+				}
+			}
+		}`)
+		return &kgateway.GatewayParameters{
+			Spec: kgateway.GatewayParametersSpec{
+				Kube: &kgateway.KubernetesProxyConfig{
+					GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+						DeploymentOverlay: &shared.KubernetesResourceOverlay{
+							Spec: &apiextensionsv1.JSON{Raw: specPatch},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-deployment",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "kgateway-proxy",
+							Env: []corev1.EnvVar{
+								{Name: "ENVOY_UID", Value: "0"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	objs := []client.Object{deployment}
+
+	// GatewayClass level first, then Gateway level, matching the deployer's order.
+	objs, err := NewOverlayApplierFromGatewayParameters(envOverlay("text")).ApplyOverlays(objs)
+	require.NoError(t, err)
+	objs, err = NewOverlayApplierFromGatewayParameters(envOverlay("json")).ApplyOverlays(objs)
+	require.NoError(t, err)
+
+	require.Len(t, objs, 1)
+	result := objs[0].(*appsv1.Deployment)
+	require.Len(t, result.Spec.Template.Spec.Containers, 1)
+	assert.ElementsMatch(t, []corev1.EnvVar{
+		{Name: "ENVOY_UID", Value: "0"},
+		{Name: "LOG_FORMAT", Value: "json"},
+	}, result.Spec.Template.Spec.Containers[0].Env)
+}
+
+func TestOverlayApplier_ApplyOverlays_TwoLevelsMergeGeneratedObjects(t *testing.T) {
+	overlays := func(labels map[string]string, pdb, hpa, vpa string) *kgateway.GatewayParameters {
+		return &kgateway.GatewayParameters{
+			Spec: kgateway.GatewayParametersSpec{
+				Kube: &kgateway.KubernetesProxyConfig{
+					GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+						PodDisruptionBudget: &shared.KubernetesResourceOverlay{
+							Metadata: &shared.ObjectMetadata{Labels: labels},
+							Spec:     &apiextensionsv1.JSON{Raw: []byte(pdb)},
+						},
+						HorizontalPodAutoscaler: &shared.KubernetesResourceOverlay{
+							Metadata: &shared.ObjectMetadata{Labels: labels},
+							Spec:     &apiextensionsv1.JSON{Raw: []byte(hpa)},
+						},
+						VerticalPodAutoscaler: &shared.KubernetesResourceOverlay{
+							Metadata: &shared.ObjectMetadata{Labels: labels},
+							Spec:     &apiextensionsv1.JSON{Raw: []byte(vpa)},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	classParams := overlays(
+		map[string]string{"class": "true", "level": "class"},
+		`{"maxUnavailable": 1, "unhealthyPodEvictionPolicy": "AlwaysAllow"}`,
+		`{"minReplicas": 2, "maxReplicas": 5, "metrics": [{"type": "Resource", "resource": {"name": "cpu", "target": {"type": "Utilization", "averageUtilization": 80}}}]}`,
+		`{"updatePolicy": {"updateMode": "Auto"}, "resourcePolicy": {"containerPolicies": [{"containerName": "*", "minAllowed": {"cpu": "100m"}}]}}`,
+	)
+	gatewayParams := overlays(
+		map[string]string{"gateway": "true", "level": "gateway"},
+		`{"maxUnavailable": 2}`,
+		`{"maxReplicas": 10}`,
+		`{"updatePolicy": {"minReplicas": 2}}`,
+	)
+
+	objs := []client.Object{deploymentWithLabels(map[string]string{"app": "gw"})}
+
+	// GatewayClass level first, then Gateway level, matching the deployer's order.
+	objs, err := NewOverlayApplierFromGatewayParameters(classParams).ApplyOverlays(objs)
+	require.NoError(t, err)
+	objs, err = NewOverlayApplierFromGatewayParameters(gatewayParams).ApplyOverlays(objs)
+	require.NoError(t, err)
+	require.Len(t, objs, 4, "expected a single PDB, HPA and VPA alongside the Deployment")
+
+	wantLabels := map[string]string{"app": "gw", "class": "true", "gateway": "true", "level": "gateway"}
+
+	pdb := objs[1].(*policyv1.PodDisruptionBudget)
+	assert.Equal(t, wantLabels, pdb.Labels)
+	assert.Equal(t, 2, pdb.Spec.MaxUnavailable.IntValue())
+	require.NotNil(t, pdb.Spec.UnhealthyPodEvictionPolicy)
+	assert.Equal(t, policyv1.AlwaysAllow, *pdb.Spec.UnhealthyPodEvictionPolicy)
+	assert.Equal(t, map[string]string{"app": "gw"}, pdb.Spec.Selector.MatchLabels)
+
+	hpa := objs[2].(*autoscalingv2.HorizontalPodAutoscaler)
+	assert.Equal(t, wantLabels, hpa.Labels)
+	assert.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+	require.NotNil(t, hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+	require.Len(t, hpa.Spec.Metrics, 1)
+	assert.Equal(t, "cpu", string(hpa.Spec.Metrics[0].Resource.Name))
+
+	vpa := objs[3].(*unstructured.Unstructured)
+	assert.Equal(t, wantLabels, vpa.GetLabels())
+	updateMode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+	assert.Equal(t, "Auto", updateMode)
+	minReplicas, _, _ := unstructured.NestedFieldNoCopy(vpa.Object, "spec", "updatePolicy", "minReplicas")
+	assert.EqualValues(t, 2, minReplicas)
+	containerPolicies, _, _ := unstructured.NestedSlice(vpa.Object, "spec", "resourcePolicy", "containerPolicies")
+	assert.Len(t, containerPolicies, 1, "expected the GatewayClass resourcePolicy to be kept")
+	targetName, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+	assert.Equal(t, pdb.Name, targetName)
+}
+
+func TestOverlayApplier_ApplyOverlays_TwoLevelsReplaceDisruptionBudgetChoice(t *testing.T) {
+	pdbOverlay := func(spec string) *kgateway.GatewayParameters {
+		return &kgateway.GatewayParameters{
+			Spec: kgateway.GatewayParametersSpec{
+				Kube: &kgateway.KubernetesProxyConfig{
+					GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+						PodDisruptionBudget: &shared.KubernetesResourceOverlay{
+							Spec: &apiextensionsv1.JSON{Raw: []byte(spec)},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		classSpec          string
+		gatewaySpec        string
+		wantMinAvailable   *intstr.IntOrString
+		wantMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			name:               "gateway maxUnavailable replaces class minAvailable",
+			classSpec:          `{"minAvailable": 1}`,
+			gatewaySpec:        `{"maxUnavailable": 1}`,
+			wantMaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+		{
+			name:             "gateway minAvailable replaces class maxUnavailable",
+			classSpec:        `{"maxUnavailable": 2}`,
+			gatewaySpec:      `{"minAvailable": "50%"}`,
+			wantMinAvailable: ptr.To(intstr.FromString("50%")),
+		},
+		{
+			name:             "gateway overlay without either field keeps the class choice",
+			classSpec:        `{"minAvailable": 1}`,
+			gatewaySpec:      `{"unhealthyPodEvictionPolicy": "AlwaysAllow"}`,
+			wantMinAvailable: ptr.To(intstr.FromInt32(1)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := []client.Object{deploymentWithLabels(map[string]string{"app": "gw"})}
+			objs, err := NewOverlayApplierFromGatewayParameters(pdbOverlay(tt.classSpec)).ApplyOverlays(objs)
+			require.NoError(t, err)
+			objs, err = NewOverlayApplierFromGatewayParameters(pdbOverlay(tt.gatewaySpec)).ApplyOverlays(objs)
+			require.NoError(t, err)
+			require.Len(t, objs, 2)
+
+			pdb := objs[1].(*policyv1.PodDisruptionBudget)
+			assert.Equal(t, tt.wantMinAvailable, pdb.Spec.MinAvailable)
+			assert.Equal(t, tt.wantMaxUnavailable, pdb.Spec.MaxUnavailable)
+		})
+	}
+}
+
+func TestOverlayApplier_ApplyOverlays_Idempotent(t *testing.T) {
+	params := &kgateway.GatewayParameters{
+		Spec: kgateway.GatewayParametersSpec{
+			Kube: &kgateway.KubernetesProxyConfig{
+				GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+					DeploymentOverlay: &shared.KubernetesResourceOverlay{
+						Metadata: &shared.ObjectMetadata{
+							Labels: map[string]string{"custom-label": "custom-value"},
+						},
+						Spec: &apiextensionsv1.JSON{Raw: []byte(`{
+							"template": {
+								"spec": {
+									"containers": [{
+										"name": "kgateway-proxy",
+										"env": [{"name": "LOG_FORMAT", "value": "json"}]
+									}]
+								}
+							}
+						}`)},
+					},
+					PodDisruptionBudget: &shared.KubernetesResourceOverlay{
+						Spec: &apiextensionsv1.JSON{Raw: []byte(`{"minAvailable": 1}`)},
+					},
+					HorizontalPodAutoscaler: &shared.KubernetesResourceOverlay{
+						Spec: &apiextensionsv1.JSON{Raw: []byte(`{"maxReplicas": 5}`)},
+					},
+					VerticalPodAutoscaler: &shared.KubernetesResourceOverlay{
+						Spec: &apiextensionsv1.JSON{Raw: []byte(`{"updatePolicy": {"updateMode": "Auto"}}`)},
+					},
+				},
+			},
+		},
+	}
+	newObjs := func() []client.Object {
+		deployment := deploymentWithLabels(map[string]string{"app": "gw"})
+		deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: "kgateway-proxy"}}
+		return []client.Object{deployment}
+	}
+
+	applier := NewOverlayApplierFromGatewayParameters(params)
+	once, err := applier.ApplyOverlays(newObjs())
+	require.NoError(t, err)
+	require.Len(t, once, 4)
+
+	twice, err := applier.ApplyOverlays(newObjs())
+	require.NoError(t, err)
+	twice, err = applier.ApplyOverlays(twice)
+	require.NoError(t, err)
+
+	assert.Equal(t, once, twice)
+}
+
+func TestOverlayApplier_ApplyOverlays_DispatchesByKind(t *testing.T) {
+	params := &kgateway.GatewayParameters{
+		Spec: kgateway.GatewayParametersSpec{
+			Kube: &kgateway.KubernetesProxyConfig{
+				GatewayParametersOverlays: kgateway.GatewayParametersOverlays{
+					ServiceOverlay: &shared.KubernetesResourceOverlay{
+						Metadata: &shared.ObjectMetadata{
+							Labels: map[string]string{"svc": "modified"},
+						},
+					},
+					HorizontalPodAutoscaler: &shared.KubernetesResourceOverlay{
+						Metadata: &shared.ObjectMetadata{
+							Labels: map[string]string{"hpa": "modified"},
+						},
+						Spec: &apiextensionsv1.JSON{Raw: []byte(`{"maxReplicas": 7}`)},
+					},
+				},
+			},
+		},
+	}
+
+	deployment := deploymentWithLabels(map[string]string{"app": "gw"})
+	existingHPA := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: deployment.Name, Namespace: deployment.Namespace},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       deployment.Name,
+			},
+			MinReplicas: new(int32(2)),
+			MaxReplicas: 3,
+		},
+	}
+	objs := []client.Object{
+		deployment,
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-service"}},
+		existingHPA,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-cm"}},
+	}
+
+	objs, err := NewOverlayApplierFromGatewayParameters(params).ApplyOverlays(objs)
+	require.NoError(t, err)
+
+	// The existing HPA is patched in place rather than generated a second time.
+	require.Len(t, objs, 4)
+
+	svc := objs[1].(*corev1.Service)
+	assert.Equal(t, "modified", svc.Labels["svc"])
+
+	hpa := objs[2].(*autoscalingv2.HorizontalPodAutoscaler)
+	assert.Equal(t, "modified", hpa.Labels["hpa"])
+	assert.Equal(t, int32(7), hpa.Spec.MaxReplicas)
+	assert.Equal(t, new(int32(2)), hpa.Spec.MinReplicas, "fields not in the overlay should be retained")
+	assert.Equal(t, deployment.Name, hpa.Spec.ScaleTargetRef.Name)
+
+	cm := objs[3].(*corev1.ConfigMap)
+	assert.Empty(t, cm.Labels)
+}
+
 // deploymentWithLabels returns a Deployment carrying the given labels and a
 // matching label selector, suitable for use as the base object when testing
 // PDB / HPA / VPA creation.
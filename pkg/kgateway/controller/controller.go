@@ -68,12 +68,13 @@ func NewBaseGatewayController(
 	logger.Info("starting controllers")
 
 	// Initialize Gateway reconciler
-	if err := watchGw(cfg, helmValuesGeneratorOverride, gatewayControllerExtension); err != nil {
+	gwParams, err := watchGw(cfg, helmValuesGeneratorOverride, gatewayControllerExtension)
+	if err != nil {
 		return nil
 	}
 
 	// Initialize GatewayClass reconciler
-	if err := cfg.Mgr.Add(newGatewayClassReconciler(cfg, classInfos)); err != nil {
+	if err := cfg.Mgr.Add(newGatewayClassReconciler(cfg, classInfos, gwParams.ValidateGatewayClassParametersRef)); err != nil {
 		return err
 	}
 
@@ -84,7 +85,7 @@ func watchGw(
 	cfg GatewayConfig,
 	helmValuesGeneratorOverride HelmValuesGeneratorOverrideFunc,
 	gatewayControllerExtension pluginsdk.GatewayControllerExtension,
-) error {
+) (*internaldeployer.GatewayParameters, error) {
 	logger.Info("creating gateway deployer",
 		"ctrlname", cfg.ControllerName,
 		"server", cfg.ControlPlane.XdsHost, "port", cfg.ControlPlane.XdsPort,
@@ -105,17 +106,28 @@ func watchGw(
 	if helmValuesGeneratorOverride != nil {
 		gwParams.WithHelmValuesGeneratorOverride(helmValuesGeneratorOverride(inputs))
 	}
+	if provider, ok := gatewayControllerExtension.(deployer.ParametersRefProvider); ok {
+		gwParams.WithSupportedParametersRefs(provider.SupportedParametersRefs()...)
+	}
+
+	opts := []deployer.Option{deployer.WithManagedBy(wellknown.DefaultManagedByValue)}
+	if postProcessor, ok := gatewayControllerExtension.(deployer.ObjectPostProcessor); ok {
+		opts = append(opts, deployer.WithObjectPostProcessors(postProcessor))
+	}
+	if contributor, ok := gatewayControllerExtension.(deployer.ObjectContributor); ok {
+		opts = append(opts, deployer.WithObjectContributors(contributor))
+	}
 
 	d, err := internaldeployer.NewGatewayDeployer(
 		cfg.ControllerName,
 		cfg.Mgr.GetScheme(),
 		cfg.Client,
 		gwParams,
-		deployer.WithManagedBy(wellknown.DefaultManagedByValue),
+		opts...,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return cfg.Mgr.Add(NewGatewayReconciler(cfg, d, gwParams, gatewayControllerExtension))
+	return gwParams, cfg.Mgr.Add(NewGatewayReconciler(cfg, d, gwParams, gatewayControllerExtension))
 }
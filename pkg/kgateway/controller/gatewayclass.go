@@ -39,11 +39,14 @@ type gatewayClassReconciler struct {
 	gwClassClient         kclient.Client[*gwv1.GatewayClass]
 	client                apiclient.Client
 	queue                 controllers.Queue
+	// validateParametersRef validates GatewayClass parametersRefs against the kinds the deployer resolves
+	validateParametersRef func(*gwv1.ParametersReference) error
 }
 
 func newGatewayClassReconciler(
 	cfg GatewayConfig,
 	classInfo map[string]*deployer.GatewayClassInfo,
+	validateParametersRef func(*gwv1.ParametersReference) error,
 ) *gatewayClassReconciler {
 	filter := kclient.Filter{ObjectFilter: cfg.Client.ObjectFilter()}
 	r := &gatewayClassReconciler{
@@ -51,6 +54,7 @@ func newGatewayClassReconciler(
 		classInfo:             classInfo,
 		gwClassClient:         kclient.NewFilteredDelayed[*gwv1.GatewayClass](cfg.Client, gvr.GatewayClass, filter),
 		client:                cfg.Client,
+		validateParametersRef: validateParametersRef,
 	}
 	r.queue = controllers.NewQueue("GatewayClassController", controllers.WithReconciler(r.reconcile), controllers.WithMaxAttempts(math.MaxInt), controllers.WithRateLimiter(rateLimiter))
 	ourControllerNames := []string{cfg.ControllerName}
@@ -131,13 +135,7 @@ func (r *gatewayClassReconciler) reconcile(req types.NamespacedName) (rErr error
 
 	// Update status
 	status := gwClass.Status
-	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
-		Type:               string(gwv1.GatewayClassConditionStatusAccepted),
-		Status:             metav1.ConditionTrue,
-		Reason:             string(gwv1.GatewayClassReasonAccepted),
-		ObservedGeneration: gwClass.Generation,
-		Message:            reports.GatewayClassAcceptedMessage,
-	})
+	meta.SetStatusCondition(&status.Conditions, gatewayClassAcceptedCondition(gwClass, r.validateParametersRef))
 	if i, ok := r.classInfo[gwClass.Name]; ok {
 		status.SupportedFeatures = i.SupportedFeatures
 	}
@@ -153,6 +151,30 @@ func (r *gatewayClassReconciler) reconcile(req types.NamespacedName) (rErr error
 	return nil
 }
 
+// gatewayClassAcceptedCondition returns the Accepted condition for the given GatewayClass.
+// A GatewayClass whose parametersRef is rejected by validateParametersRef is not accepted.
+func gatewayClassAcceptedCondition(
+	gwClass *gwv1.GatewayClass,
+	validateParametersRef func(*gwv1.ParametersReference) error,
+) metav1.Condition {
+	if err := validateParametersRef(gwClass.Spec.ParametersRef); err != nil {
+		return metav1.Condition{
+			Type:               string(gwv1.GatewayClassConditionStatusAccepted),
+			Status:             metav1.ConditionFalse,
+			Reason:             string(gwv1.GatewayClassReasonInvalidParameters),
+			ObservedGeneration: gwClass.Generation,
+			Message:            err.Error(),
+		}
+	}
+	return metav1.Condition{
+		Type:               string(gwv1.GatewayClassConditionStatusAccepted),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(gwv1.GatewayClassReasonAccepted),
+		ObservedGeneration: gwClass.Generation,
+		Message:            reports.GatewayClassAcceptedMessage,
+	}
+}
+
 func (r *gatewayClassReconciler) reconcileGatewayClasses() error {
 	var errs []error
 	for name, info := range r.classInfo {
@@ -172,7 +194,18 @@ func (r *gatewayClassReconciler) reconcileGatewayClass(name string, info *deploy
 	// Build desired GatewayClass with only fields we want to manage via SSA
 	desired := r.buildDesiredGatewayClass(name, info)
 
-	// Always apply using SSA - SSA is idempotent and will only update if needed
+	// spec.controllerName is immutable, so an existing class with another controller name (e.g.
+	// created by the user, or by an install configured with a different controller name) cannot
+	// be updated; applying would fail on every retry. Leave such a class untouched rather than
+	// taking over its labels, annotations and description.
+	if existing := r.gwClassClient.Get(name, metav1.NamespaceNone); existing != nil && existing.Spec.ControllerName != desired.Spec.ControllerName {
+		logger.Warn("skipping GatewayClass with a different controller name",
+			"name", name, "controller_name", existing.Spec.ControllerName, "expected_controller_name", desired.Spec.ControllerName)
+		return nil
+	}
+
+	// Always apply using SSA - SSA is idempotent and will only update if needed.
+	// The queue runs a single worker and dedupes keys, so applies never run concurrently.
 	logger.Debug("applying GatewayClass via SSA", "name", name)
 	if err := r.applyGatewayClass(desired, r.getControllerName(name)); err != nil {
 		return fmt.Errorf("error applying GatewayClass %s: %w", name, err)
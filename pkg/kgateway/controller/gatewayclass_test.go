@@ -0,0 +1,243 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/kclient"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
+	internaldeployer "github.com/kgateway-dev/kgateway/v2/pkg/kgateway/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/pluginsdk/collections"
+	"github.com/kgateway-dev/kgateway/v2/pkg/reports"
+)
+
+func TestGatewayClassAcceptedCondition(t *testing.T) {
+	tests := []struct {
+		name          string
+		parametersRef *gwv1.ParametersReference
+		override      deployer.HelmValuesGenerator
+		wantStatus    metav1.ConditionStatus
+		wantReason    gwv1.GatewayClassConditionReason
+		wantMessage   string
+	}{
+		{
+			name:        "no parametersRef",
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  gwv1.GatewayClassReasonAccepted,
+			wantMessage: reports.GatewayClassAcceptedMessage,
+		},
+		{
+			name: "GatewayParameters parametersRef",
+			parametersRef: &gwv1.ParametersReference{
+				Group:     kgateway.GroupName,
+				Kind:      gwv1.Kind(wellknown.GatewayParametersGVK.Kind),
+				Name:      "params",
+				Namespace: new(gwv1.Namespace("default")),
+			},
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  gwv1.GatewayClassReasonAccepted,
+			wantMessage: reports.GatewayClassAcceptedMessage,
+		},
+		{
+			name: "unsupported kind",
+			parametersRef: &gwv1.ParametersReference{
+				Group:     "",
+				Kind:      "ConfigMap",
+				Name:      "params",
+				Namespace: new(gwv1.Namespace("default")),
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  gwv1.GatewayClassReasonInvalidParameters,
+			wantMessage: "got /ConfigMap",
+		},
+		{
+			name: "unsupported group",
+			parametersRef: &gwv1.ParametersReference{
+				Group:     "example.com",
+				Kind:      gwv1.Kind(wellknown.GatewayParametersGVK.Kind),
+				Name:      "params",
+				Namespace: new(gwv1.Namespace("default")),
+			},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  gwv1.GatewayClassReasonInvalidParameters,
+			wantMessage: "got example.com/GatewayParameters",
+		},
+		{
+			name: "override resolves its own parametersRef",
+			parametersRef: &gwv1.ParametersReference{
+				Group:     "",
+				Kind:      "ConfigMap",
+				Name:      "params",
+				Namespace: new(gwv1.Namespace("default")),
+			},
+			override:    &testHelmValuesGenerator{},
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  gwv1.GatewayClassReasonAccepted,
+			wantMessage: reports.GatewayClassAcceptedMessage,
+		},
+		{
+			name: "override declares supported parametersRef",
+			parametersRef: &gwv1.ParametersReference{
+				Group:     "example.com",
+				Kind:      "ProxyConfig",
+				Name:      "params",
+				Namespace: new(gwv1.Namespace("default")),
+			},
+			override:    &testParametersRefHelmValuesGenerator{supported: []schema.GroupKind{{Group: "example.com", Kind: "ProxyConfig"}}},
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  gwv1.GatewayClassReasonAccepted,
+			wantMessage: reports.GatewayClassAcceptedMessage,
+		},
+		{
+			name: "override rejects undeclared parametersRef",
+			parametersRef: &gwv1.ParametersReference{
+				Group:     "",
+				Kind:      "ConfigMap",
+				Name:      "params",
+				Namespace: new(gwv1.Namespace("default")),
+			},
+			override:    &testParametersRefHelmValuesGenerator{supported: []schema.GroupKind{{Group: "example.com", Kind: "ProxyConfig"}}},
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  gwv1.GatewayClassReasonInvalidParameters,
+			wantMessage: "gateway.kgateway.dev/GatewayParameters, example.com/ProxyConfig, got /ConfigMap",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gwc := &gwv1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "gwc", Generation: 3},
+				Spec: gwv1.GatewayClassSpec{
+					ControllerName: gatewayControllerName,
+					ParametersRef:  tt.parametersRef,
+				},
+			}
+
+			gwParams := internaldeployer.NewGatewayParameters(nil, &deployer.Inputs{
+				CommonCollections: &collections.CommonCollections{},
+			})
+			if tt.override != nil {
+				gwParams.WithHelmValuesGeneratorOverride(tt.override)
+			}
+
+			cond := gatewayClassAcceptedCondition(gwc, gwParams.ValidateGatewayClassParametersRef)
+
+			assert.Equal(t, string(gwv1.GatewayClassConditionStatusAccepted), cond.Type)
+			assert.Equal(t, tt.wantStatus, cond.Status)
+			assert.Equal(t, string(tt.wantReason), cond.Reason)
+			assert.Contains(t, cond.Message, tt.wantMessage)
+			assert.Equal(t, int64(3), cond.ObservedGeneration)
+		})
+	}
+}
+
+type testHelmValuesGenerator struct{}
+
+func (g *testHelmValuesGenerator) GetValues(context.Context, client.Object) (map[string]any, error) {
+	return nil, nil
+}
+
+func (g *testHelmValuesGenerator) GetCacheSyncHandlers() []cache.InformerSynced {
+	return nil
+}
+
+// testParametersRefHelmValuesGenerator is a HelmValuesGenerator override that declares
+// the parametersRef group/kinds it supports.
+type testParametersRefHelmValuesGenerator struct {
+	testHelmValuesGenerator
+	supported []schema.GroupKind
+}
+
+func (g *testParametersRefHelmValuesGenerator) SupportedParametersRefs() []schema.GroupKind {
+	return g.supported
+}
+
+func TestReconcileGatewayClassControllerNameDrift(t *testing.T) {
+	const className = wellknown.DefaultGatewayClassName
+
+	tests := []struct {
+		name           string
+		existing       *gwv1.GatewayClass
+		expectedApply  bool
+		expectedLabels map[string]string
+	}{
+		{
+			name: "class with the configured controller name is updated",
+			existing: &gwv1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: className, Labels: map[string]string{"tier": "old"}},
+				Spec: gwv1.GatewayClassSpec{
+					ControllerName: wellknown.DefaultGatewayControllerName,
+					Description:    new("old description"),
+				},
+			},
+			expectedApply:  true,
+			expectedLabels: map[string]string{"tier": "new"},
+		},
+		{
+			name: "class with another controller name is left untouched",
+			existing: &gwv1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: className, Labels: map[string]string{"tier": "old"}},
+				Spec: gwv1.GatewayClassSpec{
+					ControllerName: "example.com/user-controller",
+					Description:    new("old description"),
+				},
+			},
+			expectedApply:  false,
+			expectedLabels: map[string]string{"tier": "old"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+
+			client := fake.NewClient(t, tt.existing)
+			dynamicClient, ok := client.Dynamic().(*dynamicfake.FakeDynamicClient)
+			r.True(ok, "expected a fake dynamic client, got %T", client.Dynamic())
+
+			var applied *gwv1.GatewayClass
+			dynamicClient.PrependReactor("patch", "gatewayclasses", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				applied = &gwv1.GatewayClass{}
+				r.NoError(json.Unmarshal(action.(k8stesting.PatchAction).GetPatch(), applied))
+				return true, nil, nil
+			})
+
+			reconciler := &gatewayClassReconciler{
+				defaultControllerName: wellknown.DefaultGatewayControllerName,
+				gwClassClient:         kclient.New[*gwv1.GatewayClass](client),
+				client:                client,
+			}
+			client.RunAndWait(t.Context().Done())
+
+			info := &deployer.GatewayClassInfo{
+				Description: "new description",
+				Labels:      map[string]string{"tier": "new"},
+			}
+			r.NoError(reconciler.reconcileGatewayClass(className, info))
+
+			if !tt.expectedApply {
+				r.Nil(applied, "expected the GatewayClass not to be applied")
+				return
+			}
+			r.NotNil(applied, "expected the GatewayClass to be applied")
+			r.Equal(gwv1.GatewayController(wellknown.DefaultGatewayControllerName), applied.Spec.ControllerName)
+			r.Equal(new("new description"), applied.Spec.Description)
+			r.Equal(tt.expectedLabels, applied.Labels)
+		})
+	}
+}
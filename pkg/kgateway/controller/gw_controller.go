@@ -17,16 +17,20 @@ import (
 	"istio.io/istio/pkg/kube/krt"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	utilretry "k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -43,6 +47,29 @@ import (
 
 const (
 	GatewayAutoDeployAnnotationKey = "gateway.kgateway.dev/auto-deploy"
+
+	// GatewayConditionDeploymentReady summarizes whether all desired replicas of
+	// the latest spec of the proxy Deployment generated for a Gateway are ready.
+	GatewayConditionDeploymentReady = "DeploymentReady"
+
+	GatewayReasonReplicasReady       = "ReplicasReady"
+	GatewayReasonReplicasUnavailable = "ReplicasUnavailable"
+	GatewayReasonRolloutInProgress   = "RolloutInProgress"
+
+	// GatewayConditionServiceSelectorMatchesPods reports whether the generated Service has
+	// ready endpoints, i.e. whether its selector matches any ready pod. A mismatch, e.g. from an
+	// overlay changing pod labels, leaves the Service without endpoints.
+	GatewayConditionServiceSelectorMatchesPods = "ServiceSelectorMatchesPods"
+
+	GatewayReasonSelectorMatchesPods   = "SelectorMatchesPods"
+	GatewayReasonSelectorMatchesNoPods = "SelectorMatchesNoPods"
+
+	// GatewayConditionEffectiveConfig summarizes the resolved image, log level and resources
+	// of the proxy container generated for a Gateway. It is only set when the
+	// EnableGatewayEffectiveConfig setting is enabled, and removed when it is disabled.
+	GatewayConditionEffectiveConfig = "EffectiveConfig"
+
+	GatewayReasonResolved = "Resolved"
 )
 
 var logger = logging.New("gateway-controller")
@@ -56,6 +83,8 @@ type gatewayReconciler struct {
 	scheme         *runtime.Scheme
 	controllerName string
 	enableEnvoy    bool
+	// enableEffectiveConfig sets the EffectiveConfig condition on Gateways
+	enableEffectiveConfig bool
 
 	gwClient         kclient.Client[*gwv1.Gateway]
 	gwClassClient    kclient.Client[*gwv1.GatewayClass]
@@ -65,6 +94,8 @@ type gatewayReconciler struct {
 	deploymentClient kclient.Client[*appsv1.Deployment]
 	svcAccountClient kclient.Client[*corev1.ServiceAccount]
 	configMapClient  kclient.Client[*corev1.ConfigMap]
+	// endpointSliceClient shares its informer with the Kubernetes backend plugin
+	endpointSliceClient kclient.Client[*discoveryv1.EndpointSlice]
 
 	controllerExtension pluginsdk.GatewayControllerExtension
 
@@ -79,12 +110,13 @@ func NewGatewayReconciler(
 ) *gatewayReconciler {
 	filter := kclient.Filter{ObjectFilter: cfg.Client.ObjectFilter()}
 	r := &gatewayReconciler{
-		deployer:            deployer,
-		gwParams:            gwParams,
-		scheme:              cfg.Mgr.GetScheme(),
-		controllerName:      cfg.ControllerName,
-		enableEnvoy:         cfg.CommonCollections.Settings.EnableEnvoy,
-		controllerExtension: controllerExtension,
+		deployer:              deployer,
+		gwParams:              gwParams,
+		scheme:                cfg.Mgr.GetScheme(),
+		controllerName:        cfg.ControllerName,
+		enableEnvoy:           cfg.CommonCollections.Settings.EnableEnvoy,
+		enableEffectiveConfig: cfg.CommonCollections.Settings.EnableGatewayEffectiveConfig,
+		controllerExtension:   controllerExtension,
 
 		gwClient:         kclient.NewFilteredDelayed[*gwv1.Gateway](cfg.Client, gvr.KubernetesGateway, filter),
 		gwClassClient:    kclient.NewFilteredDelayed[*gwv1.GatewayClass](cfg.Client, gvr.GatewayClass, filter),
@@ -93,6 +125,8 @@ func NewGatewayReconciler(
 		deploymentClient: kclient.NewFiltered[*appsv1.Deployment](cfg.Client, filter),
 		svcAccountClient: kclient.NewFiltered[*corev1.ServiceAccount](cfg.Client, filter),
 		configMapClient:  kclient.NewFiltered[*corev1.ConfigMap](cfg.Client, filter),
+
+		endpointSliceClient: kclient.NewFiltered[*discoveryv1.EndpointSlice](cfg.Client, filter),
 	}
 
 	// Reuse the parameter client from the deployer to avoid duplicate watches
@@ -162,38 +196,38 @@ func NewGatewayReconciler(
 	})
 	// gwParamEventHandler is a handler that reconciles Gateways based on GatewayParameters changes
 	gwParamEventHandler := controllers.ObjectHandler(func(o controllers.Object) {
-		gwpName := o.GetName()
-		gwpNamespace := o.GetNamespace()
+		gwpRef := kubeutils.NamespacedNameFrom(o)
+		gk, ok := r.parametersGroupKind(o)
+		if !ok {
+			logger.Debug("skip reconciling Gateways for parameters of unknown kind", "gwparam", gwpRef)
+			return
+		}
 
-		// 1. Look up Gateways directly using this GatewayParameters object (via spec.infrastructure.parametersRef)
-		gateways := gatewaysByParamsRef.Lookup(types.NamespacedName{Namespace: gwpNamespace, Name: gwpName})
-		for _, gw := range gateways {
-			logger.Debug("reconciling Gateway due to GatewayParameters change",
-				"ref", kubeutils.NamespacedNameFrom(gw), "gwparam", types.NamespacedName{Namespace: gwpNamespace, Name: gwpName})
-			r.queue.AddObject(gw)
+		// 1. Look up Gateways directly using this parameters object (via spec.infrastructure.parametersRef)
+		candidates := map[types.NamespacedName]*gwv1.Gateway{}
+		for _, gw := range gatewaysByParamsRef.Lookup(gwpRef) {
+			candidates[kubeutils.NamespacedNameFrom(gw)] = gw
 		}
 
-		// 2. Look up GatewayClasses using this GatewayParameters object (via spec.parametersRef)
+		// 2. Look up Gateways of the GatewayClasses using this parameters object (via spec.parametersRef)
 		gwClasses := r.gwClassClient.List(metav1.NamespaceAll, labels.Everything())
-		// For each GatewayClass that references this parameter, find all Gateways using that class
 		for _, gc := range gwClasses {
-			// Only process GatewayClasses managed by our controller
-			if gc.Spec.ControllerName != gwv1.GatewayController(r.controllerName) {
+			ref := gc.Spec.ParametersRef
+			if ref == nil || ref.Name != gwpRef.Name || ref.Namespace == nil || string(*ref.Namespace) != gwpRef.Namespace {
 				continue
 			}
-			if gc.Spec.ParametersRef != nil &&
-				gc.Spec.ParametersRef.Name == gwpName &&
-				gc.Spec.ParametersRef.Namespace != nil && string(*gc.Spec.ParametersRef.Namespace) == gwpNamespace {
-				// This GatewayClass references our GatewayParameters, find all Gateways using this class
-				gateways := gatewaysByClass.Lookup(types.NamespacedName{Name: gc.Name})
-				for _, gw := range gateways {
-					logger.Debug("reconciling Gateway due to GatewayParameters change via GatewayClass",
-						"ref", kubeutils.NamespacedNameFrom(gw), "gwparam", types.NamespacedName{Namespace: gwpNamespace, Name: gwpName},
-						"gwclass", gc.Name)
-					r.queue.AddObject(gw)
-				}
+			for _, gw := range gatewaysByClass.Lookup(types.NamespacedName{Name: gc.Name}) {
+				candidates[kubeutils.NamespacedNameFrom(gw)] = gw
 			}
 		}
+
+		gateways := internaldeployer.GatewaysForGatewayParameters(
+			r.controllerName, gk, gwpRef, gwClasses, slices.Collect(maps.Values(candidates)))
+		for _, gw := range gateways {
+			logger.Debug("reconciling Gateway due to GatewayParameters change",
+				"ref", kubeutils.NamespacedNameFrom(gw), "gwparam", gwpRef)
+			r.queue.AddObject(gw)
+		}
 	})
 	if r.gwParamClient != nil {
 		r.gwParamClient.AddEventHandler(gwParamEventHandler)
@@ -214,6 +248,19 @@ func NewGatewayReconciler(
 	r.svcClient.AddEventHandler(parentHandler)
 	r.configMapClient.AddEventHandler(parentHandler)
 
+	// Reconcile the Gateway owning a Service when the Service gains its first ready endpoint or loses
+	// its last one, so that the ServiceSelectorMatchesPods condition follows the live pods
+	r.endpointSliceClient.AddEventHandler(
+		controllers.FromEventHandler(func(o controllers.Event) {
+			if o.Event == controllers.EventUpdate && hasReadyEndpoints(o.Old) == hasReadyEndpoints(o.New) {
+				return
+			}
+			if ref, ok := r.gatewayForEndpointSlice(o.Latest()); ok {
+				logger.Debug("reconciling Gateway due to EndpointSlice change", "ref", ref, "endpointslice", kubeutils.NamespacedNameFrom(o.Latest()))
+				r.queue.Add(ref)
+			}
+		}))
+
 	// Register controller extensions
 	if controllerExtension != nil {
 		controllerExtension.Register(r.queue, gwParamEventHandler)
@@ -242,6 +289,7 @@ func (r *gatewayReconciler) Start(ctx context.Context) error {
 		r.svcAccountClient.HasSynced,
 		r.svcClient.HasSynced,
 		r.configMapClient.HasSynced,
+		r.endpointSliceClient.HasSynced,
 	}
 	// Add GatewayParameters cache sync handlers
 	hasSynced = append(hasSynced, r.gwParams.GetCacheSyncHandlers()...)
@@ -262,6 +310,7 @@ func (r *gatewayReconciler) Start(ctx context.Context) error {
 		r.svcAccountClient,
 		r.svcClient,
 		r.configMapClient,
+		r.endpointSliceClient,
 	}
 	if r.gwParamClient != nil {
 		clients = append(clients, r.gwParamClient)
@@ -289,7 +338,7 @@ func (r *gatewayReconciler) Reconcile(req types.NamespacedName) (rErr error) {
 	// make sure we're the right controller for this
 	gwc := r.gwClassClient.Get(string(gw.Spec.GatewayClassName), "")
 	if gwc == nil {
-		return fmt.Errorf("gatewayclass %s not found for gateway %s", gw.Spec.GatewayClassName, req)
+		return fmt.Errorf("%w: %s for gateway %s", internaldeployer.ErrGatewayClassNotFound, gw.Spec.GatewayClassName, req)
 	}
 
 	// Only reconcile Gateways for enabled controllers
@@ -312,6 +361,13 @@ func (r *gatewayReconciler) Reconcile(req types.NamespacedName) (rErr error) {
 			// status is reported from translator, so return normally
 			return err
 		}
+		if errors.Is(err, internaldeployer.ErrGatewayClassNotFound) ||
+			errors.Is(err, internaldeployer.ErrXdsCACertificateNotYetAvailable) {
+			// transient, e.g. the GatewayClass is being recreated or the xDS CA is not
+			// mounted yet; requeue without marking the Gateway as having invalid parameters
+			logger.Debug("transient error getting objects to deploy, requeueing", "ref", req, "error", err)
+			return err
+		}
 		// if we fail to either reference a valid GatewayParameters or
 		// the GatewayParameters configuration leads to issues building the
 		// objects, we want to set the status to InvalidParameters.
@@ -368,9 +424,260 @@ func (r *gatewayReconciler) Reconcile(req types.NamespacedName) (rErr error) {
 		return fmt.Errorf("error updating status for Gateway %s: %w", req, err)
 	}
 
+	if err := r.updateGatewayConditions(ctx, gw, r.gatewayConditions(gw, objs)); err != nil {
+		return fmt.Errorf("error updating conditions for Gateway %s: %w", req, err)
+	}
+
 	return nil
 }
 
+// conditionChange describes how a reconcile changes one of the Gateway status conditions
+// owned by this controller.
+type conditionChange int
+
+const (
+	// conditionKeep leaves the condition as is, e.g. while its inputs are not in the cache yet.
+	conditionKeep conditionChange = iota
+	// conditionSet sets the condition.
+	conditionSet
+	// conditionRemove removes the condition, as it no longer applies to the Gateway.
+	conditionRemove
+)
+
+// gatewayConditionsUpdate collects the DeploymentReady, ServiceSelectorMatchesPods and
+// EffectiveConfig condition changes of a reconcile, so that they are written to the Gateway
+// status in a single update.
+type gatewayConditionsUpdate struct {
+	set    []metav1.Condition
+	remove []string
+}
+
+func (u *gatewayConditionsUpdate) add(conditionType string, condition metav1.Condition, change conditionChange) {
+	switch change {
+	case conditionSet:
+		u.set = append(u.set, condition)
+	case conditionRemove:
+		u.remove = append(u.remove, conditionType)
+	}
+}
+
+// apply sets and removes the collected conditions on status and reports whether it changed.
+func (u gatewayConditionsUpdate) apply(status *gwv1.GatewayStatus) bool {
+	changed := false
+	for _, condition := range u.set {
+		if meta.SetStatusCondition(&status.Conditions, condition) {
+			changed = true
+		}
+	}
+	for _, conditionType := range u.remove {
+		if meta.RemoveStatusCondition(&status.Conditions, conditionType) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// gatewayConditions returns the changes to the DeploymentReady, ServiceSelectorMatchesPods and
+// EffectiveConfig conditions of gw for the generated objects objs.
+func (r *gatewayReconciler) gatewayConditions(gw *gwv1.Gateway, objs []client.Object) gatewayConditionsUpdate {
+	var update gatewayConditionsUpdate
+
+	condition, change := r.deploymentReadyCondition(gw, objs)
+	update.add(GatewayConditionDeploymentReady, condition, change)
+
+	condition, change = r.serviceSelectorCondition(gw, objs)
+	update.add(GatewayConditionServiceSelectorMatchesPods, condition, change)
+
+	change = conditionRemove
+	if r.enableEffectiveConfig {
+		var ok bool
+		if condition, ok = effectiveConfigCondition(gw, objs); ok {
+			change = conditionSet
+		}
+	}
+	update.add(GatewayConditionEffectiveConfig, condition, change)
+
+	return update
+}
+
+// updateGatewayConditions writes update to the status of gw in a single update, comparing it
+// against the latest Gateway to skip the write when nothing changed.
+func (r *gatewayReconciler) updateGatewayConditions(ctx context.Context, gw *gwv1.Gateway, update gatewayConditionsUpdate) error {
+	return updateGatewayStatusWithRetryFunc(
+		ctx,
+		r.gwClient,
+		client.ObjectKeyFromObject(gw),
+		func(latest *gwv1.Gateway) (gwv1.GatewayStatus, bool) {
+			newStatus := latest.Status.DeepCopy()
+			changed := update.apply(newStatus)
+			return *newStatus, changed
+		},
+	)
+}
+
+// deploymentReadyCondition returns the DeploymentReady condition for gw from the status of the
+// Deployment generated for it. Gateways without a generated Deployment are left untouched, as
+// are Gateways whose Deployment is not in the cache yet, since its add event requeues the Gateway.
+// Deployment status changes requeue the Gateway through the parent handler.
+func (r *gatewayReconciler) deploymentReadyCondition(gw *gwv1.Gateway, objs []client.Object) (metav1.Condition, conditionChange) {
+	var generated *appsv1.Deployment
+	for _, obj := range objs {
+		if dep, ok := obj.(*appsv1.Deployment); ok {
+			generated = dep
+			break
+		}
+	}
+	if generated == nil {
+		return metav1.Condition{}, conditionKeep
+	}
+	dep := r.deploymentClient.Get(generated.Name, generated.Namespace)
+	if dep == nil {
+		return metav1.Condition{}, conditionKeep
+	}
+	return deploymentReadyConditionFor(gw, dep), conditionSet
+}
+
+// serviceSelectorCondition returns the ServiceSelectorMatchesPods condition for gw based on
+// the ready endpoints in the EndpointSlices of the generated Service. The condition is removed
+// when no Service was generated, the Service is gone or it has no selector, as endpoints are then
+// not managed by Kubernetes. It is kept while the Service has no ready endpoints and the
+// generated Deployment has no available replicas yet, as that is reported by the DeploymentReady
+// condition and the EndpointSlice controller may not have caught up.
+func (r *gatewayReconciler) serviceSelectorCondition(gw *gwv1.Gateway, objs []client.Object) (metav1.Condition, conditionChange) {
+	var generated *corev1.Service
+	var generatedDep *appsv1.Deployment
+	for _, obj := range objs {
+		switch o := obj.(type) {
+		case *corev1.Service:
+			if generated == nil {
+				generated = o
+			}
+		case *appsv1.Deployment:
+			if generatedDep == nil {
+				generatedDep = o
+			}
+		}
+	}
+	if generated == nil {
+		return metav1.Condition{}, conditionRemove
+	}
+	svc := r.svcClient.Get(generated.Name, generated.Namespace)
+	if svc == nil || len(svc.Spec.Selector) == 0 {
+		return metav1.Condition{}, conditionRemove
+	}
+
+	ready := 0
+	for _, slice := range r.endpointSliceClient.List(svc.Namespace, labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: svc.Name})) {
+		ready += readyEndpoints(slice)
+	}
+	if ready == 0 && generatedDep != nil {
+		dep := r.deploymentClient.Get(generatedDep.Name, generatedDep.Namespace)
+		if dep == nil || dep.Status.AvailableReplicas == 0 {
+			return metav1.Condition{}, conditionKeep
+		}
+	}
+	// the message must not depend on the number of endpoints, so that scaling the proxy does
+	// not cause a status write
+	condition := metav1.Condition{
+		Type:               GatewayConditionServiceSelectorMatchesPods,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: gw.Generation,
+		Reason:             GatewayReasonSelectorMatchesPods,
+		Message:            fmt.Sprintf("Service %s selector matches ready pods", svc.Name),
+	}
+	if ready == 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GatewayReasonSelectorMatchesNoPods
+		condition.Message = fmt.Sprintf("Service %s selector %s matches no ready pods; the Service has no ready endpoints",
+			svc.Name, labels.SelectorFromSet(svc.Spec.Selector))
+	}
+	return condition, conditionSet
+}
+
+// gatewayForEndpointSlice returns the Gateway that controls the Service of the EndpointSlice, if any.
+func (r *gatewayReconciler) gatewayForEndpointSlice(o controllers.Object) (types.NamespacedName, bool) {
+	svcName := o.GetLabels()[discoveryv1.LabelServiceName]
+	if svcName == "" {
+		return types.NamespacedName{}, false
+	}
+	svc := r.svcClient.Get(svcName, o.GetNamespace())
+	if svc == nil {
+		return types.NamespacedName{}, false
+	}
+	owner := metav1.GetControllerOf(svc)
+	if owner == nil || owner.Kind != wellknown.GatewayKind || owner.APIVersion != gwv1.GroupVersion.String() {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: svc.Namespace, Name: owner.Name}, true
+}
+
+// hasReadyEndpoints reports whether o is an EndpointSlice with at least one ready endpoint.
+func hasReadyEndpoints(o controllers.Object) bool {
+	slice, ok := o.(*discoveryv1.EndpointSlice)
+	return ok && readyEndpoints(slice) > 0
+}
+
+// readyEndpoints returns the number of ready endpoints in slice. Per the EndpointSlice API, an
+// unset ready condition means the endpoint is ready.
+func readyEndpoints(slice *discoveryv1.EndpointSlice) int {
+	n := 0
+	for _, e := range slice.Endpoints {
+		if ptr.Deref(e.Conditions.Ready, true) {
+			n++
+		}
+	}
+	return n
+}
+
+// effectiveConfigCondition returns the EffectiveConfig condition for gw, summarizing the proxy
+// container of the generated Deployment after GatewayParameters and overlays were applied. It
+// returns false when no Deployment with a proxy container was generated.
+func effectiveConfigCondition(gw *gwv1.Gateway, objs []client.Object) (metav1.Condition, bool) {
+	summary, ok := internaldeployer.EffectiveConfigSummary(objs)
+	if !ok {
+		return metav1.Condition{}, false
+	}
+	return metav1.Condition{
+		Type:               GatewayConditionEffectiveConfig,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: gw.Generation,
+		Reason:             GatewayReasonResolved,
+		Message:            summary,
+	}, true
+}
+
+// deploymentReadyConditionFor returns the DeploymentReady condition for gw, which is True
+// when the latest spec of the Deployment is rolled out and it has at least as many ready
+// replicas as desired. Messages do not include replica counts, so that the condition only
+// changes, and the Gateway status is only written, on transitions.
+func deploymentReadyConditionFor(gw *gwv1.Gateway, dep *appsv1.Deployment) metav1.Condition {
+	// Kubernetes defaults an unset replica count to 1
+	desired := int32(1)
+	if dep.Spec.Replicas != nil {
+		desired = *dep.Spec.Replicas
+	}
+	condition := metav1.Condition{
+		Type:               GatewayConditionDeploymentReady,
+		ObservedGeneration: gw.Generation,
+	}
+	switch {
+	case dep.Status.ObservedGeneration < dep.Generation || dep.Status.UpdatedReplicas < desired:
+		// the status still describes the replicas of the previous spec
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GatewayReasonRolloutInProgress
+		condition.Message = fmt.Sprintf("Deployment %s rollout in progress", dep.Name)
+	case dep.Status.ReadyReplicas < desired:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = GatewayReasonReplicasUnavailable
+		condition.Message = fmt.Sprintf("Deployment %s has fewer ready replicas than desired", dep.Name)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = GatewayReasonReplicasReady
+		condition.Message = fmt.Sprintf("Deployment %s has all desired replicas ready", dep.Name)
+	}
+	return condition
+}
+
 func (r *gatewayReconciler) updateStatus(ctx context.Context, gw *gwv1.Gateway, svcMeta *metav1.ObjectMeta) error {
 	var svc *corev1.Service
 	if svcMeta != nil {
@@ -562,6 +869,17 @@ func convertIngressAddr(ing corev1.LoadBalancerIngress) (gwv1.GatewayStatusAddre
 	return gwv1.GatewayStatusAddress{}, false
 }
 
+// parametersGroupKind returns the group and kind of the parameters object o. The GatewayParameters
+// event handler is also registered by controller extensions for their own parameter kinds, so the
+// kind is resolved through the scheme first and then from the object's type metadata.
+func (r *gatewayReconciler) parametersGroupKind(o client.Object) (schema.GroupKind, bool) {
+	if gvk, err := apiutil.GVKForObject(o, r.scheme); err == nil {
+		return gvk.GroupKind(), true
+	}
+	gk := o.GetObjectKind().GroupVersionKind().GroupKind()
+	return gk, !gk.Empty()
+}
+
 func fetchGatewaysByParametersRef(
 	gw *gwv1.Gateway,
 ) *types.NamespacedName {
@@ -0,0 +1,393 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"istio.io/istio/pkg/kube/kclient"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapifake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+)
+
+func TestDeploymentReadyCondition(t *testing.T) {
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw", Generation: 3},
+	}
+	newDeployment := func(replicas *int32, ready int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw", Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: replicas},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				UpdatedReplicas:    ptr.Deref(replicas, 1),
+				ReadyReplicas:      ready,
+			},
+		}
+	}
+	staleGeneration := newDeployment(new(int32(2)), 2)
+	staleGeneration.Generation = 3
+	partiallyUpdated := newDeployment(new(int32(2)), 2)
+	partiallyUpdated.Status.UpdatedReplicas = 1
+
+	tests := []struct {
+		name        string
+		deployment  *appsv1.Deployment
+		wantStatus  metav1.ConditionStatus
+		wantReason  string
+		wantMessage string
+	}{
+		{
+			name:        "all replicas ready",
+			deployment:  newDeployment(new(int32(2)), 2),
+			wantStatus:  metav1.ConditionTrue,
+			wantReason:  GatewayReasonReplicasReady,
+			wantMessage: "Deployment gw has all desired replicas ready",
+		},
+		{
+			name:        "replicas unavailable",
+			deployment:  newDeployment(new(int32(2)), 1),
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  GatewayReasonReplicasUnavailable,
+			wantMessage: "Deployment gw has fewer ready replicas than desired",
+		},
+		{
+			name:        "unset replicas defaults to one",
+			deployment:  newDeployment(nil, 0),
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  GatewayReasonReplicasUnavailable,
+			wantMessage: "Deployment gw has fewer ready replicas than desired",
+		},
+		{
+			name:        "status of a previous generation",
+			deployment:  staleGeneration,
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  GatewayReasonRolloutInProgress,
+			wantMessage: "Deployment gw rollout in progress",
+		},
+		{
+			name:        "replicas not yet updated",
+			deployment:  partiallyUpdated,
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  GatewayReasonRolloutInProgress,
+			wantMessage: "Deployment gw rollout in progress",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond := deploymentReadyConditionFor(gw, tt.deployment)
+			assert.Equal(t, GatewayConditionDeploymentReady, cond.Type)
+			assert.Equal(t, tt.wantStatus, cond.Status)
+			assert.Equal(t, tt.wantReason, cond.Reason)
+			assert.Equal(t, tt.wantMessage, cond.Message)
+			assert.Equal(t, gw.Generation, cond.ObservedGeneration)
+		})
+	}
+}
+
+func TestEffectiveConfigCondition(t *testing.T) {
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw", Generation: 4},
+	}
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+	}
+	dep.Spec.Template.Spec.Containers = []corev1.Container{{
+		Name:  wellknown.KgatewayContainerName,
+		Image: "example.com/envoy:1.2.3",
+		Args:  []string{"--disable-hot-restart", "--log-level", "debug"},
+	}}
+
+	cond, ok := effectiveConfigCondition(gw, []client.Object{dep})
+	assert.True(t, ok)
+	assert.Equal(t, GatewayConditionEffectiveConfig, cond.Type)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, GatewayReasonResolved, cond.Reason)
+	assert.Equal(t, "image=example.com/envoy:1.2.3 logLevel=debug requests=none limits=none", cond.Message)
+	assert.Equal(t, gw.Generation, cond.ObservedGeneration)
+
+	_, ok = effectiveConfigCondition(gw, nil)
+	assert.False(t, ok)
+}
+
+func TestServiceSelectorCondition(t *testing.T) {
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw", Generation: 2},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app.kubernetes.io/name": "gw"},
+		},
+	}
+	newDeployment := func(available int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: available},
+		}
+	}
+	dep := newDeployment(1)
+	newEndpointSlice := func(name string, addresses ...string) *discoveryv1.EndpointSlice {
+		slice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: svc.Name},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+		}
+		for _, addr := range addresses {
+			slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+				Addresses:  []string{addr},
+				Conditions: discoveryv1.EndpointConditions{Ready: new(true)},
+			})
+		}
+		return slice
+	}
+	newReconciler := func(t *testing.T, objs ...client.Object) *gatewayReconciler {
+		cli := fake.NewClient(t, objs...)
+		r := &gatewayReconciler{
+			svcClient:           kclient.New[*corev1.Service](cli),
+			deploymentClient:    kclient.New[*appsv1.Deployment](cli),
+			endpointSliceClient: kclient.New[*discoveryv1.EndpointSlice](cli),
+		}
+		cli.RunAndWait(t.Context().Done())
+		return r
+	}
+
+	t.Run("live Service with ready endpoints", func(t *testing.T) {
+		r := newReconciler(t, svc, dep, newEndpointSlice("gw-abc", "10.0.0.1"), newEndpointSlice("gw-def", "10.0.0.2"))
+
+		cond, change := r.serviceSelectorCondition(gw, []client.Object{svc, dep})
+		assert.Equal(t, conditionSet, change)
+		assert.Equal(t, GatewayConditionServiceSelectorMatchesPods, cond.Type)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, GatewayReasonSelectorMatchesPods, cond.Reason)
+		assert.Equal(t, "Service gw selector matches ready pods", cond.Message)
+		assert.Equal(t, gw.Generation, cond.ObservedGeneration)
+	})
+
+	t.Run("message does not depend on the number of endpoints", func(t *testing.T) {
+		one, change := newReconciler(t, svc, dep, newEndpointSlice("gw-abc", "10.0.0.1")).
+			serviceSelectorCondition(gw, []client.Object{svc, dep})
+		assert.Equal(t, conditionSet, change)
+		three, change := newReconciler(t, svc, dep, newEndpointSlice("gw-abc", "10.0.0.1", "10.0.0.2", "10.0.0.3")).
+			serviceSelectorCondition(gw, []client.Object{svc, dep})
+		assert.Equal(t, conditionSet, change)
+		assert.Equal(t, one, three)
+	})
+
+	t.Run("live Service without matching pods", func(t *testing.T) {
+		// the EndpointSlice controller keeps an empty slice for a Service whose selector matches nothing
+		r := newReconciler(t, svc, dep, newEndpointSlice("gw-abc"))
+
+		cond, change := r.serviceSelectorCondition(gw, []client.Object{svc, dep})
+		assert.Equal(t, conditionSet, change)
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, GatewayReasonSelectorMatchesNoPods, cond.Reason)
+		assert.Contains(t, cond.Message, "app.kubernetes.io/name=gw")
+	})
+
+	t.Run("endpoints that are not ready are ignored", func(t *testing.T) {
+		slice := newEndpointSlice("gw-abc", "10.0.0.1", "10.0.0.2")
+		slice.Endpoints[0].Conditions.Ready = new(false)
+		slice.Endpoints[1].Conditions = discoveryv1.EndpointConditions{Ready: new(false), Terminating: new(true)}
+		r := newReconciler(t, svc, dep, slice)
+
+		cond, change := r.serviceSelectorCondition(gw, []client.Object{svc, dep})
+		assert.Equal(t, conditionSet, change)
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	})
+
+	t.Run("endpoints of another Service are ignored", func(t *testing.T) {
+		other := newEndpointSlice("other-abc", "10.0.0.1")
+		other.Labels[discoveryv1.LabelServiceName] = "other"
+		r := newReconciler(t, svc, dep, other)
+
+		cond, change := r.serviceSelectorCondition(gw, []client.Object{svc, dep})
+		assert.Equal(t, conditionSet, change)
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	})
+
+	t.Run("Deployment without available replicas", func(t *testing.T) {
+		unavailable := newDeployment(0)
+		r := newReconciler(t, svc, unavailable, newEndpointSlice("gw-abc"))
+
+		_, change := r.serviceSelectorCondition(gw, []client.Object{svc, unavailable})
+		assert.Equal(t, conditionKeep, change)
+	})
+
+	t.Run("Deployment not in the cache yet", func(t *testing.T) {
+		r := newReconciler(t, svc)
+
+		_, change := r.serviceSelectorCondition(gw, []client.Object{svc, dep})
+		assert.Equal(t, conditionKeep, change)
+	})
+
+	t.Run("no generated Service", func(t *testing.T) {
+		r := newReconciler(t, svc)
+
+		_, change := r.serviceSelectorCondition(gw, nil)
+		assert.Equal(t, conditionRemove, change)
+	})
+
+	t.Run("Service deleted", func(t *testing.T) {
+		r := newReconciler(t)
+
+		_, change := r.serviceSelectorCondition(gw, []client.Object{svc})
+		assert.Equal(t, conditionRemove, change)
+	})
+
+	t.Run("Service without selector", func(t *testing.T) {
+		noSelector := &corev1.Service{ObjectMeta: svc.ObjectMeta}
+		r := newReconciler(t, noSelector)
+
+		_, change := r.serviceSelectorCondition(gw, []client.Object{noSelector})
+		assert.Equal(t, conditionRemove, change)
+	})
+}
+
+func TestUpdateGatewayConditions(t *testing.T) {
+	staleCondition := func(conditionType string) metav1.Condition {
+		return metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: 1,
+			Reason:             "Stale",
+			Message:            "stale",
+			LastTransitionTime: metav1.Now(),
+		}
+	}
+	gw := &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw", Generation: 2},
+		Status: gwv1.GatewayStatus{
+			Conditions: []metav1.Condition{
+				staleCondition(GatewayConditionDeploymentReady),
+				staleCondition(GatewayConditionServiceSelectorMatchesPods),
+				staleCondition(GatewayConditionEffectiveConfig),
+			},
+		},
+	}
+	// the Service has no selector, so its endpoints are not managed by Kubernetes
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw"}}
+	replicas := int32(1)
+	dep := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gw", Generation: 1},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			UpdatedReplicas:    1,
+			ReadyReplicas:      1,
+			AvailableReplicas:  1,
+		},
+	}
+	dep.Spec.Template.Spec.Containers = []corev1.Container{{
+		Name:  wellknown.KgatewayContainerName,
+		Image: "example.com/envoy:1.2.3",
+	}}
+	objs := []client.Object{svc, dep}
+
+	cli := fake.NewClient(t, gw, svc, dep)
+	r := &gatewayReconciler{
+		gwClient:            kclient.New[*gwv1.Gateway](cli),
+		svcClient:           kclient.New[*corev1.Service](cli),
+		deploymentClient:    kclient.New[*appsv1.Deployment](cli),
+		endpointSliceClient: kclient.New[*discoveryv1.EndpointSlice](cli),
+	}
+	cli.RunAndWait(t.Context().Done())
+	gatewayAPI := cli.GatewayAPI().(*gatewayapifake.Clientset)
+	statusWrites := func() int {
+		n := 0
+		for _, action := range gatewayAPI.Actions() {
+			if action.GetVerb() == "update" && action.GetSubresource() == "status" {
+				n++
+			}
+		}
+		return n
+	}
+	conditionTypes := func() []string {
+		var types []string
+		for _, c := range r.gwClient.Get(gw.Name, gw.Namespace).Status.Conditions {
+			types = append(types, c.Type)
+		}
+		return types
+	}
+
+	// EffectiveConfig enabled: DeploymentReady and EffectiveConfig are refreshed and the stale
+	// ServiceSelectorMatchesPods condition is removed, all in a single status write
+	r.enableEffectiveConfig = true
+	require.NoError(t, r.updateGatewayConditions(t.Context(), gw, r.gatewayConditions(gw, objs)))
+	assert.Equal(t, 1, statusWrites())
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		latest := r.gwClient.Get(gw.Name, gw.Namespace)
+		assert.ElementsMatch(c, []string{GatewayConditionDeploymentReady, GatewayConditionEffectiveConfig}, conditionTypes())
+		cond := meta.FindStatusCondition(latest.Status.Conditions, GatewayConditionEffectiveConfig)
+		if assert.NotNil(c, cond) {
+			assert.Equal(c, gw.Generation, cond.ObservedGeneration)
+			assert.Contains(c, cond.Message, "image=example.com/envoy:1.2.3")
+		}
+	}, time.Second, 10*time.Millisecond)
+
+	// nothing changed: the update is compared against the latest Gateway, not the stale gw
+	require.NoError(t, r.updateGatewayConditions(t.Context(), gw, r.gatewayConditions(gw, objs)))
+	assert.Equal(t, 1, statusWrites())
+
+	// EffectiveConfig disabled: the condition is removed
+	r.enableEffectiveConfig = false
+	require.NoError(t, r.updateGatewayConditions(t.Context(), gw, r.gatewayConditions(gw, objs)))
+	assert.Equal(t, 2, statusWrites())
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, []string{GatewayConditionDeploymentReady}, conditionTypes())
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGatewayForEndpointSlice(t *testing.T) {
+	newService := func(name string, owner *metav1.OwnerReference) *corev1.Service {
+		svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+		if owner != nil {
+			svc.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return svc
+	}
+	gatewayOwner := &metav1.OwnerReference{
+		APIVersion: gwv1.GroupVersion.String(),
+		Kind:       wellknown.GatewayKind,
+		Name:       "gw",
+		Controller: new(true),
+	}
+	cli := fake.NewClient(t,
+		newService("gw-svc", gatewayOwner),
+		newService("unowned", nil),
+	)
+	r := &gatewayReconciler{svcClient: kclient.New[*corev1.Service](cli)}
+	cli.RunAndWait(t.Context().Done())
+	newEndpointSlice := func(svcName string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      svcName + "-abc",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: svcName},
+		}}
+	}
+
+	ref, ok := r.gatewayForEndpointSlice(newEndpointSlice("gw-svc"))
+	assert.True(t, ok)
+	assert.Equal(t, types.NamespacedName{Namespace: "default", Name: "gw"}, ref)
+
+	_, ok = r.gatewayForEndpointSlice(newEndpointSlice("unowned"))
+	assert.False(t, ok)
+	_, ok = r.gatewayForEndpointSlice(newEndpointSlice("missing"))
+	assert.False(t, ok)
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"maps"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"istio.io/istio/pkg/kube/krt"
 	istiolog "istio.io/istio/pkg/log"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
@@ -224,42 +226,63 @@ func pluginFactoryWithBuiltin(cfg StartConfig) extensions2.K8sGatewayExtensionsF
 	}
 }
 
+// xdsServiceHost returns the host the data plane connects to for xDS config: XdsServiceHost if
+// set, otherwise the FQDN of the XdsServiceName Service in the given (install) namespace.
+func xdsServiceHost(globalSettings *apisettings.Settings, namespace string) (string, error) {
+	if globalSettings.XdsServiceHost != "" {
+		return globalSettings.XdsServiceHost, nil
+	}
+	if errs := validation.IsDNS1035Label(globalSettings.XdsServiceName); len(errs) > 0 {
+		return "", fmt.Errorf("invalid xDS service name %q: %s", globalSettings.XdsServiceName, strings.Join(errs, "; "))
+	}
+	// Ensure exactly one trailing dot so this is an absolute (rooted) DNS name.
+	// Without it, the in-cluster FQDN has 4 dots and is treated as relative under
+	// the default ndots:5 resolver config, so Envoy's strict_dns cluster expands
+	// every search domain on each re-resolution cycle, producing a burst of
+	// NXDOMAIN lookups. TrimSuffix keeps this idempotent in case the FQDN is
+	// already rooted (e.g. a CLUSTER_DOMAIN env var set with a trailing dot),
+	// avoiding a double dot that would itself break resolution.
+	return strings.TrimSuffix(kubeutils.ServiceFQDN(metav1.ObjectMeta{
+		Name:      globalSettings.XdsServiceName,
+		Namespace: namespace,
+	}), ".") + ".", nil
+}
+
+// controlPlaneInfo returns the control plane connection details the deployer renders into the
+// proxy config, for a control plane installed in the given namespace.
+func controlPlaneInfo(globalSettings *apisettings.Settings, namespace string) (deployer.ControlPlaneInfo, error) {
+	xdsHost, err := xdsServiceHost(globalSettings, namespace)
+	if err != nil {
+		return deployer.ControlPlaneInfo{}, err
+	}
+	return deployer.ControlPlaneInfo{
+		XdsHost:            xdsHost,
+		XdsPort:            globalSettings.XdsServicePort,
+		XdsTLS:             globalSettings.XdsTLS,
+		XdsTlsCaPath:       xds.TLSRootCAPath,
+		XdsTlsCaBestEffort: globalSettings.XdsTLSCaBestEffort,
+	}, nil
+}
+
 func (c *ControllerBuilder) Build(ctx context.Context) error {
 	slog.Info("creating gateway controllers")
 
 	globalSettings := c.cfg.SetupOpts.GlobalSettings
 
-	xdsHost := globalSettings.XdsServiceHost
-	if xdsHost == "" {
-		// Ensure exactly one trailing dot so this is an absolute (rooted) DNS name.
-		// Without it, the in-cluster FQDN has 4 dots and is treated as relative under
-		// the default ndots:5 resolver config, so Envoy's strict_dns cluster expands
-		// every search domain on each re-resolution cycle, producing a burst of
-		// NXDOMAIN lookups. TrimSuffix keeps this idempotent in case the FQDN is
-		// already rooted (e.g. a CLUSTER_DOMAIN env var set with a trailing dot),
-		// avoiding a double dot that would itself break resolution.
-		xdsHost = strings.TrimSuffix(kubeutils.ServiceFQDN(metav1.ObjectMeta{
-			Name:      globalSettings.XdsServiceName,
-			Namespace: namespaces.GetPodNamespace(),
-		}), ".") + "."
+	controlPlane, err := controlPlaneInfo(globalSettings, namespaces.GetPodNamespace())
+	if err != nil {
+		return err
 	}
-
-	xdsPort := globalSettings.XdsServicePort
-	slog.Info("got xds address for deployer", "xds_host", xdsHost, "xds_port", xdsPort)
+	slog.Info("got xds address for deployer", "xds_host", controlPlane.XdsHost, "xds_port", controlPlane.XdsPort)
 
 	istioAutoMtlsEnabled := globalSettings.EnableIstioAutoMtls
 
 	gwCfg := GatewayConfig{
-		Client:         c.cfg.Client,
-		Mgr:            c.mgr,
-		ControllerName: c.cfg.ControllerName,
-		EnableEnvoy:    globalSettings.EnableEnvoy,
-		ControlPlane: deployer.ControlPlaneInfo{
-			XdsHost:      xdsHost,
-			XdsPort:      xdsPort,
-			XdsTLS:       globalSettings.XdsTLS,
-			XdsTlsCaPath: xds.TLSRootCAPath,
-		},
+		Client:               c.cfg.Client,
+		Mgr:                  c.mgr,
+		ControllerName:       c.cfg.ControllerName,
+		EnableEnvoy:          globalSettings.EnableEnvoy,
+		ControlPlane:         controlPlane,
 		IstioAutoMtlsEnabled: istioAutoMtlsEnabled,
 		ImageInfo: &deployer.ImageInfo{
 			Registry:   globalSettings.DefaultImageRegistry,
@@ -56,3 +56,60 @@ func TestGetDefaultClassInfoAppliesParametersRefs(t *testing.T) {
 	require.Equal(t, gwv1.Group(wellknown.GatewayParametersGVK.Group), classInfos[waypointClass].ParametersRef.Group)
 	require.Equal(t, gwv1.Kind(wellknown.GatewayParametersGVK.Kind), classInfos[waypointClass].ParametersRef.Kind)
 }
+
+func TestControlPlaneInfoXdsAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		settings    *apisettings.Settings
+		want        string
+		expectedErr string
+	}{
+		{
+			name:     "default service name",
+			settings: &apisettings.Settings{XdsServiceName: wellknown.DefaultXdsService},
+			want:     wellknown.DefaultXdsService + ".kgateway-system.svc.cluster.local.",
+		},
+		{
+			name:     "custom service name",
+			settings: &apisettings.Settings{XdsServiceName: "tenant-a-xds"},
+			want:     "tenant-a-xds.kgateway-system.svc.cluster.local.",
+		},
+		{
+			name: "host overrides service name",
+			settings: &apisettings.Settings{
+				XdsServiceHost: "xds.example.com",
+				XdsServiceName: "tenant-a-xds",
+			},
+			want: "xds.example.com",
+		},
+		{
+			name:        "invalid service name",
+			settings:    &apisettings.Settings{XdsServiceName: "Tenant_A.xds"},
+			expectedErr: `invalid xDS service name "Tenant_A.xds"`,
+		},
+		{
+			name:        "empty service name",
+			settings:    &apisettings.Settings{},
+			expectedErr: `invalid xDS service name ""`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.settings.XdsServicePort = 9977
+			controlPlane, err := controlPlaneInfo(tt.settings, "kgateway-system")
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			// the deployer renders this host and port as the proxy's xDS cluster address
+			require.Equal(t, tt.want, controlPlane.XdsHost)
+			require.Equal(t, uint32(9977), controlPlane.XdsPort)
+		})
+	}
+}
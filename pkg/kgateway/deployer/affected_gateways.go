@@ -0,0 +1,58 @@
+package deployer
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// GatewaysForGatewayParameters returns the Gateways, out of gateways, that a change to the
+// parameters object gwpRef of kind gk would affect: Gateways in its namespace that reference it
+// via spec.infrastructure.parametersRef, and Gateways whose GatewayClass, out of classes and
+// managed by controllerName, references it via spec.parametersRef. Each Gateway is returned at
+// most once. Only references to exactly gk match. The Gateway controller uses it to requeue
+// Gateways on parameter changes, and it can be used for impact analysis before editing
+// parameters shared by several Gateways.
+func GatewaysForGatewayParameters(
+	controllerName string,
+	gk schema.GroupKind,
+	gwpRef types.NamespacedName,
+	classes []*gwv1.GatewayClass,
+	gateways []*gwv1.Gateway,
+) []*gwv1.Gateway {
+	affectedClasses := sets.New[string]()
+	for _, gwc := range classes {
+		if gwc.Spec.ControllerName == gwv1.GatewayController(controllerName) && classReferencesParameters(gwc, gk, gwpRef) {
+			affectedClasses.Insert(gwc.Name)
+		}
+	}
+
+	var out []*gwv1.Gateway
+	for _, gw := range gateways {
+		if gatewayReferencesParameters(gw, gk, gwpRef) || affectedClasses.Has(string(gw.Spec.GatewayClassName)) {
+			out = append(out, gw)
+		}
+	}
+	return out
+}
+
+func classReferencesParameters(gwc *gwv1.GatewayClass, gk schema.GroupKind, gwpRef types.NamespacedName) bool {
+	ref := gwc.Spec.ParametersRef
+	return ref != nil &&
+		referencesKind(string(ref.Group), string(ref.Kind), gk) &&
+		ref.Name == gwpRef.Name &&
+		ref.Namespace != nil && string(*ref.Namespace) == gwpRef.Namespace
+}
+
+func gatewayReferencesParameters(gw *gwv1.Gateway, gk schema.GroupKind, gwpRef types.NamespacedName) bool {
+	if gw.Namespace != gwpRef.Namespace || gw.Spec.Infrastructure == nil || gw.Spec.Infrastructure.ParametersRef == nil {
+		return false
+	}
+	ref := gw.Spec.Infrastructure.ParametersRef
+	return referencesKind(string(ref.Group), string(ref.Kind), gk) && ref.Name == gwpRef.Name
+}
+
+func referencesKind(group, kind string, gk schema.GroupKind) bool {
+	return group == gk.Group && kind == gk.Kind
+}
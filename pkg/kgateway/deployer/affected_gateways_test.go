@@ -0,0 +1,130 @@
+package deployer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+)
+
+func TestGatewaysForGatewayParameters(t *testing.T) {
+	gwpRef := types.NamespacedName{Namespace: "infra", Name: "shared-params"}
+
+	classWithParams := &gwv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "with-params"},
+		Spec: gwv1.GatewayClassSpec{
+			ControllerName: wellknown.DefaultGatewayControllerName,
+			ParametersRef: &gwv1.ParametersReference{
+				Group:     kgateway.GroupName,
+				Kind:      gwv1.Kind(wellknown.GatewayParametersGVK.Kind),
+				Name:      gwpRef.Name,
+				Namespace: new(gwv1.Namespace(gwpRef.Namespace)),
+			},
+		},
+	}
+	classWithoutParams := &gwv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "without-params"},
+		Spec: gwv1.GatewayClassSpec{
+			ControllerName: wellknown.DefaultGatewayControllerName,
+		},
+	}
+	foreignClass := classWithParams.DeepCopy()
+	foreignClass.Name = "foreign"
+	foreignClass.Spec.ControllerName = "example.com/other-controller"
+	configMapClass := classWithParams.DeepCopy()
+	configMapClass.Name = "configmap-params"
+	configMapClass.Spec.ParametersRef.Group = ""
+	configMapClass.Spec.ParametersRef.Kind = "ConfigMap"
+
+	newGateway := func(namespace, name, className, paramsName string) *gwv1.Gateway {
+		gw := &gwv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       gwv1.GatewaySpec{GatewayClassName: gwv1.ObjectName(className)},
+		}
+		if paramsName != "" {
+			gw.Spec.Infrastructure = &gwv1.GatewayInfrastructure{
+				ParametersRef: &gwv1.LocalParametersReference{
+					Group: kgateway.GroupName,
+					Kind:  gwv1.Kind(wellknown.GatewayParametersGVK.Kind),
+					Name:  paramsName,
+				},
+			}
+		}
+		return gw
+	}
+
+	classes := []*gwv1.GatewayClass{classWithParams, classWithoutParams, foreignClass, configMapClass}
+	gateways := []*gwv1.Gateway{
+		// affected through the GatewayClass
+		newGateway("default", "class-gw-1", classWithParams.Name, ""),
+		newGateway("other", "class-gw-2", classWithParams.Name, ""),
+		// affected directly and through the GatewayClass; must be returned once
+		newGateway("infra", "both", classWithParams.Name, gwpRef.Name),
+		// affected directly
+		newGateway("infra", "direct", classWithoutParams.Name, gwpRef.Name),
+		// not affected: same params name in another namespace
+		newGateway("default", "other-namespace", classWithoutParams.Name, gwpRef.Name),
+		// not affected: class is managed by another controller
+		newGateway("default", "foreign", foreignClass.Name, ""),
+		// not affected: class references another kind with the same name
+		newGateway("default", "configmap", configMapClass.Name, ""),
+		// not affected: no params at all
+		newGateway("default", "unrelated", classWithoutParams.Name, ""),
+	}
+
+	gws := GatewaysForGatewayParameters(wellknown.DefaultGatewayControllerName, wellknown.GatewayParametersGVK.GroupKind(), gwpRef, classes, gateways)
+
+	var got []types.NamespacedName
+	for _, gw := range gws {
+		got = append(got, client.ObjectKeyFromObject(gw))
+	}
+	assert.ElementsMatch(t, []types.NamespacedName{
+		{Namespace: "default", Name: "class-gw-1"},
+		{Namespace: "other", Name: "class-gw-2"},
+		{Namespace: "infra", Name: "both"},
+		{Namespace: "infra", Name: "direct"},
+	}, got)
+}
+
+func TestGatewaysForGatewayParametersMatchesOnlyKind(t *testing.T) {
+	gwpRef := types.NamespacedName{Namespace: "infra", Name: "shared-params"}
+	extensionKind := schema.GroupKind{Group: "example.com", Kind: "ExtensionParameters"}
+	newGateway := func(name string, gk schema.GroupKind) *gwv1.Gateway {
+		return &gwv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: gwpRef.Namespace, Name: name},
+			Spec: gwv1.GatewaySpec{
+				Infrastructure: &gwv1.GatewayInfrastructure{
+					ParametersRef: &gwv1.LocalParametersReference{
+						Group: gwv1.Group(gk.Group),
+						Kind:  gwv1.Kind(gk.Kind),
+						Name:  gwpRef.Name,
+					},
+				},
+			},
+		}
+	}
+	gateways := []*gwv1.Gateway{
+		newGateway("extension-params", extensionKind),
+		newGateway("gateway-params", wellknown.GatewayParametersGVK.GroupKind()),
+		newGateway("empty-kind", schema.GroupKind{}),
+	}
+
+	names := func(gws []*gwv1.Gateway) []string {
+		var out []string
+		for _, gw := range gws {
+			out = append(out, gw.Name)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"extension-params"},
+		names(GatewaysForGatewayParameters(wellknown.DefaultGatewayControllerName, extensionKind, gwpRef, nil, gateways)))
+	assert.Equal(t, []string{"gateway-params"},
+		names(GatewaysForGatewayParameters(wellknown.DefaultGatewayControllerName, wellknown.GatewayParametersGVK.GroupKind(), gwpRef, nil, gateways)))
+}
@@ -26,6 +26,13 @@ func loadChart(fs embed.FS) (*chart.Chart, error) {
 	return c, nil
 }
 
+// LoadChartFromFS loads a chart from the given filesystem, which must contain the chart folder
+// as its only entry, the same layout as the embedded charts. Combined with deployer.WithChart,
+// e.g. os.DirFS("install/helm") can be used to render a chart under development.
+func LoadChartFromFS(filesystem fs.FS) (*chart.Chart, error) {
+	return loadFs(filesystem)
+}
+
 func loadFs(filesystem fs.FS) (*chart.Chart, error) {
 	var bufferedFiles []*loader.BufferedFile
 	entries, err := fs.ReadDir(filesystem, ".")
@@ -0,0 +1,91 @@
+package deployer
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/helm"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+)
+
+// gatewayValueRef matches references to top-level gateway values in the envoy chart templates,
+// e.g. `$gateway.service` or `.Values.gateway.service`.
+var gatewayValueRef = regexp.MustCompile(`(?:\$gateway|\.Values\.gateway)\.([a-zA-Z]+)`)
+
+// unrenderedGatewayFields are HelmGateway fields that are intentionally not referenced by the chart.
+var unrenderedGatewayFields = sets.New(
+	// only used by the controller to select the chart
+	"dataPlaneType",
+)
+
+func TestEnvoyChartConsistentWithHelmGateway(t *testing.T) {
+	c, err := LoadEnvoyChart()
+	require.NoError(t, err)
+
+	referenced := sets.New[string]()
+	for _, tmpl := range c.Templates {
+		for _, m := range gatewayValueRef.FindAllStringSubmatch(string(tmpl.Data), -1) {
+			referenced.Insert(m[1])
+		}
+	}
+	require.NotEmpty(t, referenced)
+
+	fields := sets.New[string]()
+	typ := reflect.TypeFor[deployer.HelmGateway]()
+	for i := range typ.NumField() {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields.Insert(name)
+		}
+	}
+
+	assert.Empty(t, sets.List(referenced.Difference(fields)),
+		"chart templates reference gateway values that HelmGateway does not populate")
+	assert.Empty(t, sets.List(fields.Difference(referenced).Difference(unrenderedGatewayFields)),
+		"HelmGateway fields are not referenced by any chart template and would be silently dropped")
+}
+
+func TestLoadChartFromFS(t *testing.T) {
+	t.Run("embedded chart layout", func(t *testing.T) {
+		c, err := LoadChartFromFS(helm.EnvoyHelmChart)
+		require.NoError(t, err)
+		embedded, err := LoadEnvoyChart()
+		require.NoError(t, err)
+		assert.Equal(t, embedded.Name(), c.Name())
+		assert.Len(t, c.Templates, len(embedded.Templates))
+	})
+
+	t.Run("more than one chart folder", func(t *testing.T) {
+		_, err := LoadChartFromFS(fstest.MapFS{
+			"a/Chart.yaml": {Data: []byte("apiVersion: v2\nname: a\nversion: 0.0.1\n")},
+			"b/Chart.yaml": {Data: []byte("apiVersion: v2\nname: b\nversion: 0.0.1\n")},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expected exactly one entry in the chart folder")
+	})
+}
+
+func TestRenderChartToObjectsWrapsCustomChartErrors(t *testing.T) {
+	c, err := LoadChartFromFS(fstest.MapFS{
+		"custom/Chart.yaml":               {Data: []byte("apiVersion: v2\nname: custom\nversion: 0.0.1\n")},
+		"custom/templates/configmap.yaml": {Data: []byte(`{{ fail "custom chart failure" }}`)},
+	})
+	require.NoError(t, err)
+
+	d, err := NewGatewayDeployer(wellknown.DefaultGatewayControllerName, schemes.DefaultScheme(), nil, nil, deployer.WithChart(c))
+	require.NoError(t, err)
+
+	_, err = d.RenderChartToObjects("default", "gw", map[string]any{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to render helm chart for default.gw")
+	assert.Contains(t, err.Error(), "custom chart failure")
+}
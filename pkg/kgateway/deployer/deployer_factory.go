@@ -1,10 +1,17 @@
 package deployer
 
 import (
+	"fmt"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient"
 	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
 )
 
 func NewGatewayDeployer(controllerName string, scheme *runtime.Scheme, client apiclient.Client, gwParams *GatewayParameters, opts ...deployer.Option) (*deployer.Deployer, error) {
@@ -12,6 +19,46 @@ func NewGatewayDeployer(controllerName string, scheme *runtime.Scheme, client ap
 	if err != nil {
 		return nil, err
 	}
-	return deployer.NewDeployer(
-		controllerName, scheme, client, envoyChart, gwParams, GatewayReleaseNameAndNamespace, opts...), nil
+	var defaultOpts []deployer.Option
+	// the built-in values always render at least the proxy Deployment and Service; a
+	// HelmValuesGeneratorOverride may render a different set and can pass its own
+	// deployer.WithRequiredKinds instead
+	if gwParams == nil || gwParams.helmValuesGeneratorOverride == nil {
+		defaultOpts = append(defaultOpts, deployer.WithRequiredKinds(wellknown.DeploymentGVK, wellknown.ServiceGVK))
+	}
+	if gwParams != nil && gwParams.inputs != nil && gwParams.inputs.CommonCollections != nil {
+		if prefix := gwParams.inputs.CommonCollections.Settings.ListenerProtocolPodLabelPrefix; prefix != "" {
+			if errs := validation.IsDNS1123Subdomain(prefix); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid listener protocol pod label prefix %q: %s", prefix, strings.Join(errs, ", "))
+			}
+			defaultOpts = append(defaultOpts, deployer.WithPodTemplateLabels(listenerProtocolPodLabels(prefix)))
+		}
+	}
+	d := deployer.NewDeployer(
+		controllerName, scheme, client, envoyChart, gwParams, GatewayReleaseNameAndNamespace, append(defaultOpts, opts...)...)
+	if err := d.ValidateContributedKinds(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// listenerProtocolPodLabels returns a PodTemplateLabelsFunc that labels the proxy pods of a Gateway
+// with <prefix>/<protocol>=true for each of its listener protocols, e.g. example.com/https=true.
+func listenerProtocolPodLabels(prefix string) deployer.PodTemplateLabelsFunc {
+	return func(obj client.Object) map[string]string {
+		gw, ok := obj.(*gwv1.Gateway)
+		if !ok {
+			return nil
+		}
+		labels := make(map[string]string, len(gw.Spec.Listeners))
+		for _, l := range gw.Spec.Listeners {
+			key := prefix + "/" + strings.ToLower(string(l.Protocol))
+			// skip protocols that do not make a valid label name, e.g. domain-prefixed custom protocols
+			if len(validation.IsQualifiedName(key)) > 0 {
+				continue
+			}
+			labels[key] = "true"
+		}
+		return labels
+	}
 }
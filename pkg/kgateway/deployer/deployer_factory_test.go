@@ -0,0 +1,174 @@
+package deployer
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+)
+
+func TestListenerProtocolPodLabels(t *testing.T) {
+	gw := &gwv1.Gateway{
+		Spec: gwv1.GatewaySpec{
+			Listeners: []gwv1.Listener{
+				{Name: "http", Protocol: gwv1.HTTPProtocolType},
+				{Name: "https", Protocol: gwv1.HTTPSProtocolType},
+				{Name: "https-alt", Protocol: gwv1.HTTPSProtocolType},
+				{Name: "custom", Protocol: "example.com/custom"},
+			},
+		},
+	}
+
+	labels := listenerProtocolPodLabels("protocol.example.com")(gw)
+
+	assert.Equal(t, map[string]string{
+		"protocol.example.com/http":  "true",
+		"protocol.example.com/https": "true",
+	}, labels)
+}
+
+func TestNewGatewayDeployerStampsListenerProtocolPodLabels(t *testing.T) {
+	gwc := defaultGatewayClass()
+	gwParams := emptyGatewayParameters()
+	gw := defaultGateway()
+
+	ctx := t.Context()
+	fakeClient := fake.NewClient(t, gwc, gwParams)
+	inputs := defaultInputs(t, gwc, gw)
+	inputs.CommonCollections.Settings.ListenerProtocolPodLabelPrefix = "protocol.example.com"
+	d, err := NewGatewayDeployer(wellknown.DefaultGatewayControllerName, schemes.DefaultScheme(), fakeClient, NewGatewayParameters(fakeClient, inputs))
+	require.NoError(t, err)
+	fakeClient.RunAndWait(ctx.Done())
+
+	objs, err := d.GetObjsToDeploy(ctx, gw)
+	require.NoError(t, err)
+	var dep *appsv1.Deployment
+	for _, obj := range objs {
+		if deployment, ok := obj.(*appsv1.Deployment); ok {
+			dep = deployment
+		}
+	}
+	require.NotNil(t, dep)
+	assert.Equal(t, "true", dep.Spec.Template.Labels["protocol.example.com/http"])
+}
+
+func TestNewGatewayDeployerRendersXdsAddress(t *testing.T) {
+	gwc := defaultGatewayClass()
+	gwParams := emptyGatewayParameters()
+	gw := defaultGateway()
+
+	ctx := t.Context()
+	fakeClient := fake.NewClient(t, gwc, gwParams)
+	inputs := defaultInputs(t, gwc, gw)
+	inputs.ControlPlane.XdsHost = "tenant-a-xds.kgateway-system.svc.cluster.local."
+	inputs.ControlPlane.XdsPort = 19977
+	d, err := NewGatewayDeployer(wellknown.DefaultGatewayControllerName, schemes.DefaultScheme(), fakeClient, NewGatewayParameters(fakeClient, inputs))
+	require.NoError(t, err)
+	fakeClient.RunAndWait(ctx.Done())
+
+	objs, err := d.GetObjsToDeploy(ctx, gw)
+	require.NoError(t, err)
+	var bootstrap string
+	for _, obj := range objs {
+		if cm, ok := obj.(*corev1.ConfigMap); ok {
+			bootstrap = cm.Data["envoy.yaml"]
+		}
+	}
+	require.NotEmpty(t, bootstrap, "expected the proxy bootstrap config to be rendered")
+	assert.Contains(t, bootstrap, "address: tenant-a-xds.kgateway-system.svc.cluster.local.")
+	assert.Contains(t, bootstrap, "port_value: 19977")
+}
+
+func TestNewGatewayDeployerRejectsInvalidListenerProtocolPodLabelPrefix(t *testing.T) {
+	inputs := defaultInputs(t)
+	inputs.CommonCollections.Settings.ListenerProtocolPodLabelPrefix = "Not_A_Domain"
+
+	_, err := NewGatewayDeployer(wellknown.DefaultGatewayControllerName, schemes.DefaultScheme(), nil, &GatewayParameters{inputs: inputs})
+
+	assert.ErrorContains(t, err, `invalid listener protocol pod label prefix "Not_A_Domain"`)
+}
+
+// configMapValuesGenerator is a HelmValuesGenerator override for a chart that renders only a ConfigMap.
+type configMapValuesGenerator struct{}
+
+func (g *configMapValuesGenerator) GetValues(_ context.Context, obj client.Object) (map[string]any, error) {
+	return map[string]any{"gateway": map[string]any{"name": obj.GetName()}}, nil
+}
+
+func (g *configMapValuesGenerator) GetCacheSyncHandlers() []cache.InformerSynced {
+	return nil
+}
+
+func TestNewGatewayDeployerDoesNotRequireKindsForHelmValuesGeneratorOverride(t *testing.T) {
+	c, err := LoadChartFromFS(fstest.MapFS{
+		"custom/Chart.yaml": {Data: []byte(`apiVersion: v2
+name: custom
+version: 0.0.1
+`)},
+		"custom/templates/configmap.yaml": {Data: []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Values.gateway.name }}-custom
+`)},
+	})
+	require.NoError(t, err)
+	gwc := defaultGatewayClass()
+	gw := defaultGateway()
+
+	ctx := t.Context()
+	fakeClient := fake.NewClient(t, gwc)
+	gwParams := NewGatewayParameters(fakeClient, defaultInputs(t, gwc, gw)).
+		WithHelmValuesGeneratorOverride(&configMapValuesGenerator{})
+	d, err := NewGatewayDeployer(wellknown.DefaultGatewayControllerName, schemes.DefaultScheme(), fakeClient, gwParams, deployer.WithChart(c))
+	require.NoError(t, err)
+	fakeClient.RunAndWait(ctx.Done())
+
+	objs, err := d.GetObjsToDeploy(ctx, gw)
+
+	require.NoError(t, err)
+	require.Len(t, objs, 1)
+	assert.Equal(t, "foo-custom", objs[0].GetName())
+}
+
+func TestNewGatewayDeployerRejectsEmptyRenderForHelmValuesGeneratorOverride(t *testing.T) {
+	c, err := LoadChartFromFS(fstest.MapFS{
+		"custom/Chart.yaml": {Data: []byte(`apiVersion: v2
+name: custom
+version: 0.0.1
+`)},
+		"custom/templates/configmap.yaml": {Data: []byte(`{{- if .Values.enabled }}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Values.gateway.name }}-custom
+{{- end }}
+`)},
+	})
+	require.NoError(t, err)
+	gwc := defaultGatewayClass()
+	gw := defaultGateway()
+
+	ctx := t.Context()
+	fakeClient := fake.NewClient(t, gwc)
+	gwParams := NewGatewayParameters(fakeClient, defaultInputs(t, gwc, gw)).
+		WithHelmValuesGeneratorOverride(&configMapValuesGenerator{})
+	d, err := NewGatewayDeployer(wellknown.DefaultGatewayControllerName, schemes.DefaultScheme(), fakeClient, gwParams, deployer.WithChart(c))
+	require.NoError(t, err)
+	fakeClient.RunAndWait(ctx.Done())
+
+	_, err = d.GetObjsToDeploy(ctx, gw)
+
+	assert.ErrorIs(t, err, deployer.ErrNoObjectsRendered)
+}
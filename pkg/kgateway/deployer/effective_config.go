@@ -0,0 +1,57 @@
+package deployer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+)
+
+// EffectiveConfigSummary summarizes the image, log level and resources of the proxy container
+// in objs, the objects to deploy for a Gateway, e.g.
+// "image=cr.kgateway.dev/kgateway-dev/envoy-wrapper:v2.1.0 logLevel=info requests=cpu=100m limits=none".
+// It returns false when objs has no Deployment with a proxy container.
+func EffectiveConfigSummary(objs []client.Object) (string, bool) {
+	var proxy *corev1.Container
+	for _, obj := range objs {
+		dep, ok := obj.(*appsv1.Deployment)
+		if !ok {
+			continue
+		}
+		for i, c := range dep.Spec.Template.Spec.Containers {
+			if c.Name == wellknown.KgatewayContainerName {
+				proxy = &dep.Spec.Template.Spec.Containers[i]
+				break
+			}
+		}
+		break
+	}
+	if proxy == nil {
+		return "", false
+	}
+
+	logLevel := "default"
+	if i := slices.Index(proxy.Args, "--log-level"); i >= 0 && i+1 < len(proxy.Args) {
+		logLevel = proxy.Args[i+1]
+	}
+	return fmt.Sprintf("image=%s logLevel=%s requests=%s limits=%s",
+		proxy.Image, logLevel, formatResourceList(proxy.Resources.Requests), formatResourceList(proxy.Resources.Limits)), true
+}
+
+// formatResourceList formats resources as name=quantity pairs sorted by name, e.g. cpu=100m,memory=128Mi.
+func formatResourceList(resources corev1.ResourceList) string {
+	if len(resources) == 0 {
+		return "none"
+	}
+	pairs := make([]string, 0, len(resources))
+	for name, quantity := range resources {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", name, quantity.String()))
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
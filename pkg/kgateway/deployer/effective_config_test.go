@@ -0,0 +1,57 @@
+package deployer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kgateway-dev/kgateway/v2/api/v1alpha1/kgateway"
+	"github.com/kgateway-dev/kgateway/v2/pkg/apiclient/fake"
+	"github.com/kgateway-dev/kgateway/v2/pkg/kgateway/wellknown"
+	"github.com/kgateway-dev/kgateway/v2/pkg/schemes"
+)
+
+func TestEffectiveConfigSummaryReflectsResolvedParameters(t *testing.T) {
+	gwc := defaultGatewayClass()
+	gwParams := emptyGatewayParameters()
+	gwParams.Spec.Kube = &kgateway.KubernetesProxyConfig{
+		EnvoyContainer: &kgateway.EnvoyContainer{
+			Bootstrap: &kgateway.EnvoyBootstrap{LogLevel: new("debug")},
+			Image: &kgateway.Image{
+				Registry:   new("example.com"),
+				Repository: new("envoy"),
+				Tag:        new("1.2.3"),
+			},
+			Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("128Mi"),
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+				},
+			},
+		},
+	}
+	gw := defaultGateway()
+
+	ctx := t.Context()
+	fakeClient := fake.NewClient(t, gwc, gwParams)
+	d, err := NewGatewayDeployer(wellknown.DefaultGatewayControllerName, schemes.DefaultScheme(), fakeClient,
+		NewGatewayParameters(fakeClient, defaultInputs(t, gwc, gw)))
+	require.NoError(t, err)
+	fakeClient.RunAndWait(ctx.Done())
+
+	objs, err := d.GetObjsToDeploy(ctx, gw)
+	require.NoError(t, err)
+
+	summary, ok := EffectiveConfigSummary(objs)
+	require.True(t, ok)
+	assert.Equal(t, "image=example.com/envoy:1.2.3 logLevel=debug requests=cpu=100m,memory=128Mi limits=none", summary)
+}
+
+func TestEffectiveConfigSummaryWithoutProxyContainer(t *testing.T) {
+	_, ok := EffectiveConfigSummary([]client.Object{&corev1.ConfigMap{}})
+	assert.False(t, ok)
+}
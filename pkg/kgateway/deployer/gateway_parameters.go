@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"helm.sh/helm/v3/pkg/chart"
 	"istio.io/istio/pkg/kube/kclient"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +31,18 @@ var (
 
 	// ErrNotFound is returned when a requested resource is not found
 	ErrNotFound = errors.New("resource not found")
+
+	// ErrUnsupportedParametersRef is returned when a parametersRef references a resource other than GatewayParameters
+	ErrUnsupportedParametersRef = errors.New("unsupported parametersRef")
+
+	// ErrGatewayParametersRequired is returned when RequireGatewayParameters is enabled and
+	// neither the Gateway nor its GatewayClass references a GatewayParameters
+	ErrGatewayParametersRequired = errors.New("GatewayParameters required")
+
+	// ErrGatewayClassNotFound is returned when the GatewayClass of a Gateway is not found.
+	// This is usually transient (e.g. the GatewayClass is being recreated), so callers should
+	// requeue the Gateway rather than report it as invalid.
+	ErrGatewayClassNotFound = errors.New("GatewayClass not found")
 )
 
 func NewGatewayParameters(cli apiclient.Client, inputs *deployer.Inputs) *GatewayParameters {
@@ -48,12 +62,16 @@ type GatewayParameters struct {
 	inputs                      *deployer.Inputs
 	helmValuesGeneratorOverride deployer.HelmValuesGenerator
 	kgwParameters               *kgatewayParameters
+	// extraParametersRefs are the GatewayClass parametersRef group/kinds resolved outside of the
+	// built-in generator, e.g. by a gateway controller extension.
+	extraParametersRefs []schema.GroupKind
 }
 
 type kgatewayParameters struct {
-	gwParamClient kclient.Client[*kgateway.GatewayParameters]
-	gwClassClient kclient.Client[*gwv1.GatewayClass]
-	inputs        *deployer.Inputs
+	gwParamClient       kclient.Client[*kgateway.GatewayParameters]
+	gwClassClient       kclient.Client[*gwv1.GatewayClass]
+	inputs              *deployer.Inputs
+	extraParametersRefs []schema.GroupKind
 }
 
 func (gp *GatewayParameters) WithHelmValuesGeneratorOverride(generator deployer.HelmValuesGenerator) *GatewayParameters {
@@ -61,6 +79,33 @@ func (gp *GatewayParameters) WithHelmValuesGeneratorOverride(generator deployer.
 	return gp
 }
 
+// WithSupportedParametersRefs registers additional GatewayClass parametersRef group/kinds that are
+// resolved outside of the built-in generator, e.g. by a gateway controller extension. The built-in
+// generator uses its defaults for GatewayClasses referencing one of them.
+func (gp *GatewayParameters) WithSupportedParametersRefs(gks ...schema.GroupKind) *GatewayParameters {
+	gp.extraParametersRefs = append(gp.extraParametersRefs, gks...)
+	if gp.kgwParameters != nil {
+		gp.kgwParameters.extraParametersRefs = gp.extraParametersRefs
+	}
+	return gp
+}
+
+// ValidateGatewayClassParametersRef returns ErrUnsupportedParametersRef if the given GatewayClass
+// parametersRef cannot be resolved by the configured HelmValuesGenerator. The built-in generator
+// resolves GatewayParameters and any group/kinds registered with WithSupportedParametersRefs.
+// An override that does not implement deployer.ParametersRefProvider resolves its own parameters,
+// so any parametersRef is accepted.
+func (gp *GatewayParameters) ValidateGatewayClassParametersRef(ref *gwv1.ParametersReference) error {
+	if gp.helmValuesGeneratorOverride == nil {
+		return ValidateGatewayClassParametersRef(ref, gp.extraParametersRefs...)
+	}
+	provider, ok := gp.helmValuesGeneratorOverride.(deployer.ParametersRefProvider)
+	if !ok {
+		return nil
+	}
+	return ValidateGatewayClassParametersRef(ref, append(slices.Clone(gp.extraParametersRefs), provider.SupportedParametersRefs()...)...)
+}
+
 // GetGatewayParametersClient returns the GatewayParameters client if Envoy is enabled, nil otherwise.
 // This allows the reconciler to reuse the same client for watching changes.
 func (gp *GatewayParameters) GetGatewayParametersClient() kclient.Client[*kgateway.GatewayParameters] {
@@ -211,6 +256,11 @@ func (k *kgatewayParameters) getGatewayParametersForGateway(gw *gwv1.Gateway) (*
 	// attempt to get the GatewayParameters name from the Gateway. If we can't find it,
 	// we'll check for the default GWP for the GatewayClass.
 	if gw.Spec.Infrastructure == nil || gw.Spec.Infrastructure.ParametersRef == nil {
+		if k.inputs.CommonCollections.Settings.RequireGatewayParameters {
+			if err := k.requireGatewayClassParameters(gw); err != nil {
+				return nil, err
+			}
+		}
 		slog.Debug("no GatewayParameters found for Gateway, using default",
 			"gateway_name", gw.GetName(),
 			"gateway_namespace", gw.GetNamespace(),
@@ -222,10 +272,12 @@ func (k *kgatewayParameters) getGatewayParametersForGateway(gw *gwv1.Gateway) (*
 
 	gwpName := ref.Name
 	if group := ref.Group; group != kgateway.GroupName {
-		return nil, fmt.Errorf("invalid group %s for GatewayParameters", group)
+		return nil, fmt.Errorf("%w: invalid group %s for GatewayParameters; supported group/kinds: %s",
+			ErrUnsupportedParametersRef, group, supportedParametersRefs())
 	}
 	if kind := ref.Kind; kind != gwv1.Kind(wellknown.GatewayParametersGVK.Kind) {
-		return nil, fmt.Errorf("invalid kind %s for GatewayParameters", kind)
+		return nil, fmt.Errorf("%w: invalid kind %s for GatewayParameters; supported group/kinds: %s",
+			ErrUnsupportedParametersRef, kind, supportedParametersRefs())
 	}
 
 	// the GatewayParameters must live in the same namespace as the Gateway
@@ -251,6 +303,20 @@ func (k *kgatewayParameters) getGatewayParametersForGateway(gw *gwv1.Gateway) (*
 	return mergedGwp, nil
 }
 
+// requireGatewayClassParameters returns ErrGatewayParametersRequired if the GatewayClass of
+// the provided Gateway does not reference a GatewayParameters.
+func (k *kgatewayParameters) requireGatewayClassParameters(gw *gwv1.Gateway) error {
+	gwc, err := getGatewayClassFromGateway(k.gwClassClient, gw)
+	if err != nil {
+		return err
+	}
+	if gwc.Spec.ParametersRef == nil {
+		return fmt.Errorf("%w: neither Gateway %s/%s nor GatewayClass %s sets a parametersRef",
+			ErrGatewayParametersRequired, gw.GetNamespace(), gw.GetName(), gwc.GetName())
+	}
+	return nil
+}
+
 // gets the default GatewayParameters associated with the GatewayClass of the provided Gateway
 func (k *kgatewayParameters) getDefaultGatewayParameters(gw *gwv1.Gateway) (*kgateway.GatewayParameters, error) {
 	return k.getDefaultGatewayParametersWithFlag(gw, false)
@@ -265,7 +331,7 @@ func (k *kgatewayParameters) getDefaultGatewayParametersWithFlag(gw *gwv1.Gatewa
 	}
 
 	paramRef := gwc.Spec.ParametersRef
-	if paramRef == nil {
+	if paramRef == nil || isParametersRef(paramRef, k.extraParametersRefs) {
 		return deployer.GetInMemoryGatewayParameters(deployer.InMemoryGatewayParametersConfig{
 			ControllerName:             string(gwc.Spec.ControllerName),
 			ClassName:                  gwc.GetName(),
@@ -285,10 +351,40 @@ func (k *kgatewayParameters) getDefaultGatewayParametersWithFlag(gw *gwv1.Gatewa
 	return k.mergeWithDefaults(gwc, gwcParams, omit)
 }
 
+// ValidateGatewayClassParametersRef returns ErrUnsupportedParametersRef if the given
+// GatewayClass parametersRef does not reference a GatewayParameters or one of the extra group/kinds.
+func ValidateGatewayClassParametersRef(ref *gwv1.ParametersReference, extra ...schema.GroupKind) error {
+	if ref == nil {
+		return nil
+	}
+	if (ref.Group != kgateway.GroupName || string(ref.Kind) != wellknown.GatewayParametersGVK.Kind) && !isParametersRef(ref, extra) {
+		return fmt.Errorf("%w: GatewayClass parametersRef must reference one of %s, got %s/%s",
+			ErrUnsupportedParametersRef, supportedParametersRefs(extra...), ref.Group, ref.Kind)
+	}
+	return nil
+}
+
+// isParametersRef returns true if the given parametersRef references one of the given group/kinds.
+func isParametersRef(ref *gwv1.ParametersReference, gks []schema.GroupKind) bool {
+	return slices.Contains(gks, schema.GroupKind{Group: string(ref.Group), Kind: string(ref.Kind)})
+}
+
+// supportedParametersRefs returns the group/kinds accepted in a parametersRef, formatted for error messages.
+func supportedParametersRefs(extra ...schema.GroupKind) string {
+	supported := []string{kgateway.GroupName + "/" + wellknown.GatewayParametersGVK.Kind}
+	for _, gk := range extra {
+		supported = append(supported, gk.Group+"/"+gk.Kind)
+	}
+	return strings.Join(supported, ", ")
+}
+
 // resolveGatewayClassParameters fetches the raw GatewayParameters for a
 // GatewayClass without merging with defaults.
 func (k *kgatewayParameters) resolveGatewayClassParameters(gwc *gwv1.GatewayClass) (*kgateway.GatewayParameters, error) {
 	paramRef := gwc.Spec.ParametersRef
+	if err := ValidateGatewayClassParametersRef(paramRef); err != nil {
+		return nil, err
+	}
 
 	gwpName := paramRef.Name
 	if gwpName == "" {
@@ -373,7 +469,7 @@ func (k *kgatewayParameters) getValues(gw *gwv1.Gateway, gwParam *kgateway.Gatew
 
 	// Inject xDS CA certificate into Helm values if TLS is enabled
 	if k.inputs.ControlPlane.XdsTLS {
-		if err := injectXdsCACertificate(k.inputs.ControlPlane.XdsTlsCaPath, vals); err != nil {
+		if err := injectXdsCACertificate(k.inputs.ControlPlane.XdsTlsCaPath, k.inputs.ControlPlane.XdsTlsCaBestEffort, vals); err != nil {
 			return nil, fmt.Errorf("failed to inject xDS CA certificate: %w", err)
 		}
 	}
@@ -539,7 +635,7 @@ func getGatewayClassFromGateway(cli kclient.Client[*gwv1.GatewayClass], gw *gwv1
 
 	gwc := cli.Get(string(gw.Spec.GatewayClassName), metav1.NamespaceNone)
 	if gwc == nil {
-		return nil, fmt.Errorf("failed to get GatewayClass for Gateway %s/%s", gw.GetName(), gw.GetNamespace())
+		return nil, fmt.Errorf("%w: %s for Gateway %s/%s", ErrGatewayClassNotFound, gw.Spec.GatewayClassName, gw.GetNamespace(), gw.GetName())
 	}
 
 	return gwc, nil
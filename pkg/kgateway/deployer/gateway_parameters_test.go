@@ -11,6 +11,7 @@ import (
 	"istio.io/istio/pkg/util/smallset"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -110,6 +111,123 @@ func TestShouldUseExtendedGatewayParameters(t *testing.T) {
 	assert.Contains(t, vals, "testHelmValuesGenerator")
 }
 
+func TestGetValuesResolvesParametersRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		// withoutGatewayParameters leaves the default GatewayParameters out of the cluster
+		withoutGatewayParameters bool
+		requireGatewayParameters bool
+		supportedParametersRefs  []schema.GroupKind
+		modifyGatewayClass       func(gwc *gwv1.GatewayClass)
+		modifyGateway            func(gw *gwv1.Gateway)
+		wantErr                  error
+		wantErrContains          string
+	}{
+		{
+			name:                     "rejects Gateway without parameters when required",
+			withoutGatewayParameters: true,
+			requireGatewayParameters: true,
+			modifyGatewayClass: func(gwc *gwv1.GatewayClass) {
+				gwc.Spec.ParametersRef = nil
+			},
+			wantErr: ErrGatewayParametersRequired,
+		},
+		{
+			name:                     "allows GatewayClass parameters when required",
+			requireGatewayParameters: true,
+		},
+		{
+			name: "rejects unsupported GatewayClass parametersRef",
+			modifyGatewayClass: func(gwc *gwv1.GatewayClass) {
+				gwc.Spec.ParametersRef.Group = ""
+				gwc.Spec.ParametersRef.Kind = "ConfigMap"
+			},
+			wantErr: ErrUnsupportedParametersRef,
+		},
+		{
+			name:                     "uses defaults for extension GatewayClass parametersRef",
+			withoutGatewayParameters: true,
+			supportedParametersRefs:  []schema.GroupKind{{Group: "example.com", Kind: "ProxyConfig"}},
+			modifyGatewayClass: func(gwc *gwv1.GatewayClass) {
+				gwc.Spec.ParametersRef.Group = "example.com"
+				gwc.Spec.ParametersRef.Kind = "ProxyConfig"
+			},
+		},
+		{
+			name: "lists supported group/kinds for typoed Gateway parametersRef",
+			modifyGateway: func(gw *gwv1.Gateway) {
+				gw.Spec.Infrastructure = &gwv1.GatewayInfrastructure{
+					ParametersRef: &gwv1.LocalParametersReference{
+						Group: "kgateway.dev",
+						Kind:  gwv1.Kind(wellknown.GatewayParametersGVK.Kind),
+						Name:  wellknown.DefaultGatewayParametersName,
+					},
+				}
+			},
+			wantErr:         ErrUnsupportedParametersRef,
+			wantErrContains: "invalid group kgateway.dev for GatewayParameters; supported group/kinds: gateway.kgateway.dev/GatewayParameters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gwc := defaultGatewayClass()
+			if tt.modifyGatewayClass != nil {
+				tt.modifyGatewayClass(gwc)
+			}
+			gw := defaultGateway()
+			if tt.modifyGateway != nil {
+				tt.modifyGateway(gw)
+			}
+			objs := []client.Object{gwc}
+			if !tt.withoutGatewayParameters {
+				objs = append(objs, emptyGatewayParameters())
+			}
+
+			ctx := t.Context()
+			fakeClient := fake.NewClient(t, objs...)
+			inputs := defaultInputs(t, gwc, gw)
+			inputs.CommonCollections.Settings.RequireGatewayParameters = tt.requireGatewayParameters
+			gwp := NewGatewayParameters(fakeClient, inputs).WithSupportedParametersRefs(tt.supportedParametersRefs...)
+			fakeClient.RunAndWait(ctx.Done())
+			vals, err := gwp.GetValues(ctx, gw)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				if tt.wantErrContains != "" {
+					assert.ErrorContains(t, err, tt.wantErrContains)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, vals, "gateway")
+		})
+	}
+}
+
+func TestShouldRequeueUntilGatewayClassExists(t *testing.T) {
+	gwc := defaultGatewayClass()
+	gwParams := emptyGatewayParameters()
+	gw := defaultGateway()
+
+	ctx := t.Context()
+	// the GatewayClass is missing initially, e.g. while it is being recreated
+	fakeClient := fake.NewClient(t, gwParams)
+	gwp := NewGatewayParameters(fakeClient, defaultInputs(t, gwc, gw))
+	fakeClient.RunAndWait(ctx.Done())
+	_, err := gwp.GetValues(ctx, gw)
+	assert.ErrorIs(t, err, ErrGatewayClassNotFound)
+
+	_, err = fakeClient.GatewayAPI().GatewayV1().GatewayClasses().Create(ctx, gwc, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		vals, err := gwp.GetValues(ctx, gw)
+		assert.NoError(c, err)
+		assert.NotNil(c, vals)
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
 func defaultGatewayClass() *gwv1.GatewayClass {
 	return &gwv1.GatewayClass{
 		ObjectMeta: metav1.ObjectMeta{
@@ -127,6 +245,27 @@ func defaultGatewayClass() *gwv1.GatewayClass {
 	}
 }
 
+// defaultGateway returns a Gateway of the default GatewayClass with a single HTTP listener.
+func defaultGateway() *gwv1.Gateway {
+	return &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: defaultNamespace,
+			UID:       "1235",
+		},
+		Spec: gwv1.GatewaySpec{
+			GatewayClassName: wellknown.DefaultGatewayClassName,
+			Listeners: []gwv1.Listener{
+				{
+					Protocol: gwv1.HTTPProtocolType,
+					Port:     80,
+					Name:     "http",
+				},
+			},
+		},
+	}
+}
+
 func emptyGatewayParameters() *kgateway.GatewayParameters {
 	return &kgateway.GatewayParameters{
 		ObjectMeta: metav1.ObjectMeta{
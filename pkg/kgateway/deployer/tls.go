@@ -1,16 +1,40 @@
 package deployer
 
 import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
 )
 
+// ErrXdsCACertificateNotYetAvailable is returned in best-effort mode when the xDS CA certificate
+// file has not been mounted yet. Callers should requeue rather than treat it as a permanent failure.
+var ErrXdsCACertificateNotYetAvailable = errors.New("xDS CA certificate not yet available")
+
+// xdsCACertificateExpiryWarningPeriod is how long before the xDS CA certificate expires that
+// rendering starts warning about it.
+const xdsCACertificateExpiryWarningPeriod = 30 * 24 * time.Hour
+
+// loggedXdsCACertificateExpiryWarnings holds the xDS CA certificate expiry warnings already
+// logged. The CA is injected on every Gateway render, so each warning is logged once rather
+// than once per Gateway per reconcile. Warnings name the certificate and its expiry, so a
+// rotated certificate, or one that went from expiring soon to expired, is warned about again.
+var loggedXdsCACertificateExpiryWarnings sync.Map
+
 // injectXdsCACertificate reads the CA certificate from the control plane's mounted TLS Secret
 // and injects it into the Helm values so it can be used by the proxy templates.
-func injectXdsCACertificate(caCertPath string, vals *deployer.HelmConfig) error {
+// When bestEffort is set, a missing file yields ErrXdsCACertificateNotYetAvailable.
+func injectXdsCACertificate(caCertPath string, bestEffort bool, vals *deployer.HelmConfig) error {
 	if _, err := os.Stat(caCertPath); os.IsNotExist(err) {
+		if bestEffort {
+			return fmt.Errorf("%w: CA certificate file not found at %s", ErrXdsCACertificateNotYetAvailable, caCertPath)
+		}
 		return fmt.Errorf("xDS TLS is enabled but CA certificate file not found at %s. "+
 			"Ensure the xDS TLS secret is properly mounted and contains ca.crt", caCertPath,
 		)
@@ -24,6 +48,8 @@ func injectXdsCACertificate(caCertPath string, vals *deployer.HelmConfig) error
 		return fmt.Errorf("CA certificate at %s is empty", caCertPath)
 	}
 
+	warnXdsCACertificateExpiry(caCert, caCertPath, time.Now())
+
 	caCertStr := string(caCert)
 	if vals.Gateway != nil {
 		if vals.Gateway.Xds != nil && vals.Gateway.Xds.Tls != nil {
@@ -33,3 +59,42 @@ func injectXdsCACertificate(caCertPath string, vals *deployer.HelmConfig) error
 
 	return nil
 }
+
+// warnXdsCACertificateExpiry logs the expiry warning for caCert, unless it was already logged.
+// It reports whether a warning was logged.
+func warnXdsCACertificateExpiry(caCert []byte, caCertPath string, now time.Time) bool {
+	warning := xdsCACertificateExpiryWarning(caCert, now)
+	if warning == "" {
+		return false
+	}
+	if _, logged := loggedXdsCACertificateExpiryWarnings.LoadOrStore(warning, struct{}{}); logged {
+		return false
+	}
+	slog.Warn(warning, "path", caCertPath)
+	return true
+}
+
+// xdsCACertificateExpiryWarning returns a warning if a certificate in the PEM encoded caCert is
+// expired, or expires within xdsCACertificateExpiryWarningPeriod, at the given time. Proxies fail
+// to establish new xDS connections once the CA expires. Content that does not parse as a
+// certificate is ignored here and left to the proxy to reject.
+func xdsCACertificateExpiryWarning(caCert []byte, now time.Time) string {
+	for block, rest := pem.Decode(caCert); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		if now.After(cert.NotAfter) {
+			return fmt.Sprintf("xDS CA certificate %q expired at %s; proxies will fail to establish new xDS connections",
+				cert.Subject.String(), cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+		if cert.NotAfter.Sub(now) < xdsCACertificateExpiryWarningPeriod {
+			return fmt.Sprintf("xDS CA certificate %q expires at %s; rotate it before proxies fail to establish new xDS connections",
+				cert.Subject.String(), cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+	return ""
+}
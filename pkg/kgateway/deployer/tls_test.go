@@ -0,0 +1,121 @@
+package deployer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kgateway-dev/kgateway/v2/pkg/deployer"
+)
+
+func TestInjectXdsCACertificate(t *testing.T) {
+	newVals := func() *deployer.HelmConfig {
+		return &deployer.HelmConfig{
+			Gateway: &deployer.HelmGateway{
+				Xds: &deployer.HelmXds{
+					Tls: &deployer.HelmXdsTls{},
+				},
+			},
+		}
+	}
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.crt")
+	empty := filepath.Join(dir, "empty.crt")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+	valid := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(valid, []byte("test-ca"), 0o600))
+
+	t.Run("missing file requeues in best-effort mode", func(t *testing.T) {
+		err := injectXdsCACertificate(missing, true, newVals())
+		assert.ErrorIs(t, err, ErrXdsCACertificateNotYetAvailable)
+	})
+
+	t.Run("missing file fails without best-effort mode", func(t *testing.T) {
+		err := injectXdsCACertificate(missing, false, newVals())
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrXdsCACertificateNotYetAvailable)
+	})
+
+	t.Run("empty file fails in best-effort mode", func(t *testing.T) {
+		err := injectXdsCACertificate(empty, true, newVals())
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrXdsCACertificateNotYetAvailable)
+		assert.Contains(t, err.Error(), "is empty")
+	})
+
+	t.Run("valid file is injected", func(t *testing.T) {
+		vals := newVals()
+		require.NoError(t, injectXdsCACertificate(valid, true, vals))
+		assert.Equal(t, new("test-ca"), vals.Gateway.Xds.Tls.CaCert)
+	})
+}
+
+func TestXdsCACertificateExpiryWarning(t *testing.T) {
+	now := time.Now()
+
+	t.Run("valid certificate", func(t *testing.T) {
+		assert.Empty(t, xdsCACertificateExpiryWarning(newCACert(t, now.Add(365*24*time.Hour)), now))
+	})
+
+	t.Run("expired certificate", func(t *testing.T) {
+		warning := xdsCACertificateExpiryWarning(newCACert(t, now.Add(-time.Hour)), now)
+		assert.Contains(t, warning, `xDS CA certificate "CN=kgateway-xds-ca" expired at`)
+	})
+
+	t.Run("certificate close to expiry", func(t *testing.T) {
+		warning := xdsCACertificateExpiryWarning(newCACert(t, now.Add(24*time.Hour)), now)
+		assert.Contains(t, warning, `xDS CA certificate "CN=kgateway-xds-ca" expires at`)
+	})
+
+	t.Run("expired certificate in a bundle", func(t *testing.T) {
+		bundle := append(newCACert(t, now.Add(365*24*time.Hour)), newCACert(t, now.Add(-time.Hour))...)
+		assert.Contains(t, xdsCACertificateExpiryWarning(bundle, now), "expired at")
+	})
+
+	t.Run("content that is not a certificate", func(t *testing.T) {
+		assert.Empty(t, xdsCACertificateExpiryWarning([]byte("test-ca"), now))
+	})
+}
+
+func TestWarnXdsCACertificateExpiry(t *testing.T) {
+	t.Cleanup(loggedXdsCACertificateExpiryWarnings.Clear)
+	now := time.Now()
+	expiresSoon := newCACert(t, now.Add(24*time.Hour))
+
+	assert.True(t, warnXdsCACertificateExpiry(expiresSoon, "ca.crt", now), "expected the first render to warn")
+	assert.False(t, warnXdsCACertificateExpiry(expiresSoon, "ca.crt", now), "expected later renders not to warn again")
+
+	assert.True(t, warnXdsCACertificateExpiry(expiresSoon, "ca.crt", now.Add(48*time.Hour)),
+		"expected a warning once the certificate expired")
+	assert.True(t, warnXdsCACertificateExpiry(newCACert(t, now.Add(2*24*time.Hour)), "ca.crt", now),
+		"expected a warning for a rotated certificate")
+	assert.False(t, warnXdsCACertificateExpiry(newCACert(t, now.Add(365*24*time.Hour)), "ca.crt", now))
+}
+
+// newCACert returns a PEM encoded self-signed CA certificate expiring at notAfter.
+func newCACert(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kgateway-xds-ca"},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -172,7 +172,11 @@ func CloneObjectMetaForStatus(m metav1.ObjectMeta) metav1.ObjectMeta {
 	}
 }
 
-// GatewayControllerExtension is an interface for extending the Gateway controller with custom behavior
+// GatewayControllerExtension is an interface for extending the Gateway controller with custom behavior.
+// An extension may also implement deployer.ObjectPostProcessor to transform the rendered objects after
+// GatewayParameters overlays are applied, deployer.ObjectContributor to deploy additional objects
+// for each Gateway, and deployer.ParametersRefProvider to accept GatewayClass parametersRef kinds
+// that it resolves itself.
 type GatewayControllerExtension interface {
 	// Register is called to allow the extension to interact with the Queue used to reconcile Gateways,
 	// and access to a ResourceEventHandler that the extension can use to integrate additional Gateway parameter events
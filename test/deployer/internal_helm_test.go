@@ -498,6 +498,32 @@ wIDAQABMA0GCSqGSIb3DQEBCwUAA4IBAQBtestcertdata
 					"HPA should have label from GW overlay")
 			},
 		},
+		{
+			Name:      "envoy both GWC and GW have PDB, HPA and VPA overlays",
+			InputFile: "envoy-both-gwc-and-gw-have-autoscaler-overlays",
+			Validate: func(t *testing.T, outputYaml string) {
+				t.Helper()
+				// a single object of each kind is generated from both overlays
+				for _, kind := range []string{"PodDisruptionBudget", "HorizontalPodAutoscaler", "VerticalPodAutoscaler"} {
+					assert.Equal(t, 1, strings.Count(outputYaml, "kind: "+kind),
+						"a single %s should be created from both overlays", kind)
+				}
+				assert.Contains(t, outputYaml, "pdb-source: gatewayclass",
+					"PDB should keep the label from the GWC overlay")
+				assert.Contains(t, outputYaml, "pdb-shared: from-gateway",
+					"PDB label from the GW overlay should override the GWC overlay")
+				assert.Contains(t, outputYaml, "minReplicas: 2",
+					"HPA should keep minReplicas from the GWC overlay")
+				assert.Contains(t, outputYaml, "maxReplicas: 10",
+					"HPA maxReplicas from the GW overlay should override the GWC overlay")
+				assert.Contains(t, outputYaml, "vpa-source: gateway",
+					"VPA label from the GW overlay should override the GWC overlay")
+				assert.Contains(t, outputYaml, "updateMode: Auto",
+					"VPA should keep nested fields from the GWC overlay")
+				assert.NotContains(t, outputYaml, "minAllowed",
+					"VPA lists from the GW overlay should replace the GWC overlay lists")
+			},
+		},
 		{
 			Name:      "envoy with PDB and autoscalers (HPA, VPA)",
 			InputFile: "envoy-all-autoscalers",
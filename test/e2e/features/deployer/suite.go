@@ -80,6 +80,9 @@ var (
 		"TestReadinessProbeProxyProtocol": {
 			Manifests: []string{gatewayReadinessProxyProtocol},
 		},
+		"TestGatewaysWithDistinctParametersAreIsolated": {
+			Manifests: []string{gatewaysWithDistinctParams},
+		},
 	}
 )
 
@@ -519,6 +522,43 @@ func (s *testingSuite) TestHPAPDBLifecycle() {
 	}).WithTimeout(60 * time.Second).WithPolling(1 * time.Second).Should(gomega.Succeed())
 }
 
+// TestGatewaysWithDistinctParametersAreIsolated verifies that two Gateways
+// referencing different GatewayParameters each get a deployment reflecting
+// only their own parameters.
+func (s *testingSuite) TestGatewaysWithDistinctParametersAreIsolated() {
+	s.TestInstallation.AssertionsT(s.T()).EventuallyReadyReplicas(s.Ctx, proxyAObjectMeta, gomega.Equal(1))
+	s.TestInstallation.AssertionsT(s.T()).EventuallyReadyReplicas(s.Ctx, proxyBObjectMeta, gomega.Equal(2))
+
+	for _, tc := range []struct {
+		objectMeta metav1.ObjectMeta
+		paramsName string
+	}{
+		{objectMeta: proxyAObjectMeta, paramsName: "gw-params-a"},
+		{objectMeta: proxyBObjectMeta, paramsName: "gw-params-b"},
+	} {
+		objectMeta, paramsName := tc.objectMeta, tc.paramsName
+		proxyDeployment := &appsv1.Deployment{}
+		err := s.TestInstallation.ClusterContext.Client.Get(s.Ctx, client.ObjectKey{
+			Namespace: objectMeta.Namespace,
+			Name:      objectMeta.Name,
+		}, proxyDeployment)
+		s.Require().NoError(err)
+		s.Require().Equal(paramsName, proxyDeployment.Spec.Template.Labels["params"],
+			"deployment %s should only carry labels from its own GatewayParameters", objectMeta.Name)
+	}
+
+	s.TestInstallation.AssertionsT(s.T()).AssertEnvoyAdminApi(
+		s.Ctx,
+		proxyAObjectMeta,
+		serverInfoLogLevelAssertion(s.T(), s.TestInstallation, "debug", "upstream:debug"),
+	)
+	s.TestInstallation.AssertionsT(s.T()).AssertEnvoyAdminApi(
+		s.Ctx,
+		proxyBObjectMeta,
+		serverInfoLogLevelAssertion(s.T(), s.TestInstallation, "warn", "connection:trace"),
+	)
+}
+
 // patchGateway accepts a reference to an object, and a patch function. It then queries the object,
 // performs the patch in memory, and writes the object back to the cluster.
 func (s *testingSuite) patchGateway(objectMeta metav1.ObjectMeta, patchFn func(*gwv1.Gateway)) {
@@ -20,6 +20,7 @@ var (
 	gatewayWithHPAPDB             = filepath.Join(fsutils.MustGetThisDir(), "testdata", "gateway-with-hpa-pdb.yaml")
 	vpaCRDManifest                = filepath.Join(fsutils.MustGetThisDir(), "testdata", "vpa-crd.yaml")
 	gatewayReadinessProxyProtocol = filepath.Join(fsutils.MustGetThisDir(), "testdata", "gateway-readiness-proxy-protocol.yaml")
+	gatewaysWithDistinctParams    = filepath.Join(fsutils.MustGetThisDir(), "testdata", "gateways-with-distinct-parameters.yaml")
 
 	// objects
 	proxyObjectMeta = metav1.ObjectMeta{
@@ -36,4 +37,14 @@ var (
 		Name:      "gw-params-custom",
 		Namespace: "default",
 	}
+
+	proxyAObjectMeta = metav1.ObjectMeta{
+		Name:      "gw-a",
+		Namespace: "default",
+	}
+
+	proxyBObjectMeta = metav1.ObjectMeta{
+		Name:      "gw-b",
+		Namespace: "default",
+	}
 )